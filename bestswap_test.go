@@ -0,0 +1,68 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBestSwapOptions(t *testing.T) {
+	var (
+		quoteQuery url.Values
+		swapBody   map[string]json.RawMessage
+	)
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			quoteQuery = r.URL.Query()
+			serveFixture("testdata/quote.json")(w, r)
+		},
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			swapBody = nil
+			_ = json.NewDecoder(r.Body).Decode(&swapBody)
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		_, err := c.BestSwap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, jupiter.SwapModeExactIn, quoteQuery.Get("swapMode"))
+		assert.Equal(t, "true", quoteQuery.Get("asLegacyTransaction"))
+		assert.False(t, quoteQuery.Has("onlyDirectRoutes"))
+		assert.JSONEq(t, "true", string(swapBody["wrapUnwrapSOL"]))
+		assert.JSONEq(t, "true", string(swapBody["asLegacyTransaction"]))
+		assert.NotContains(t, swapBody, "computeUnitPriceMicroLamports")
+	})
+
+	t.Run("versioned transaction with priority fee", func(t *testing.T) {
+		params := params
+		params.AsLegacyTransaction = utils.Pointer(false)
+		params.WrapUnwrapSol = utils.Pointer(false)
+		params.ComputeUnitPriceMicroLamports = utils.Pointer[int64](1000)
+		params.OnlyDirectRoutes = true
+
+		_, err := c.BestSwap(params)
+		require.NoError(t, err)
+
+		assert.False(t, quoteQuery.Has("asLegacyTransaction"))
+		assert.Equal(t, "true", quoteQuery.Get("onlyDirectRoutes"))
+		assert.JSONEq(t, "false", string(swapBody["wrapUnwrapSOL"]))
+		assert.JSONEq(t, "false", string(swapBody["asLegacyTransaction"]))
+		assert.JSONEq(t, "1000", string(swapBody["computeUnitPriceMicroLamports"]))
+	})
+}
@@ -2,11 +2,15 @@ package jupiter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/dmitrymomot/jupiter/utils"
@@ -27,14 +31,37 @@ type (
 		endpointSwap      string
 		endpointPrice     string
 		endpointRoutesMap string
+		tokenListURL      string
+		tokens            TokenRegistry
+		rpcEndpoint       string
+
+		strictDecoding bool
+		failOnDrift    bool
+		driftHandler   DriftHandler
+
+		clock               Clock
+		quoteMaxAge         time.Duration
+		requote             bool
+		requoteToleranceBps uint64
+		maxSlotLag          int64
+		lastSlot            atomic.Int64 // highest context slot seen in quote responses
 	}
 
 	// ClientOption is a function that can be used to configure a Jupiter client.
 	ClientOption func(*Client)
 
+	// Clock tells the current time and waits, it can be replaced in tests.
+	Clock interface {
+		Now() time.Time
+		After(d time.Duration) <-chan time.Time
+	}
+
+	// systemClock is the clock of the system.
+	systemClock struct{}
+
 	// Response is a generic response structure.
 	Response struct {
-		Data        json.RawMessage `json:"data"`
+		Data        json.RawMessage `json:"data" drift:"required"`
 		TimeTaken   float64         `json:"timeTaken"`
 		ContextSlot int64           `json:"contextSlot"`
 	}
@@ -52,6 +79,9 @@ func NewClient(opts ...ClientOption) *Client {
 		endpointSwap:      "/swap",
 		endpointPrice:     "/price",
 		endpointRoutesMap: "/indexed-route-map",
+		tokenListURL:      "https://token.jup.ag/strict",
+
+		clock: systemClock{},
 	}
 
 	for _, opt := range opts {
@@ -61,16 +91,33 @@ func NewClient(opts ...ClientOption) *Client {
 	return c
 }
 
+// Now returns the current time.
+func (systemClock) Now() time.Time { return time.Now() }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// now returns the current time of the client clock.
+func (c *Client) now() time.Time {
+	return c.clock.Now()
+}
+
 // get makes a GET request to the specified endpoint with the given parameters.
 // It returns the response as is without parsing or any error encountered.
 // The caller is responsible for closing the response body.
-func (c *Client) get(endpoint string, params interface{}) (*http.Response, error) {
+func (c *Client) get(ctx context.Context, endpoint string, params interface{}) (*http.Response, error) {
+	return c.getURL(ctx, c.apiURL+endpoint, params)
+}
+
+// getURL makes a GET request to the specified absolute URL with the given parameters.
+// The caller is responsible for closing the response body.
+func (c *Client) getURL(ctx context.Context, rawURL string, params interface{}) (*http.Response, error) {
 	uv, err := utils.StructToUrlValues(params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert params to url values: %w", err)
 	}
 
-	parsedURL, err := url.Parse(c.apiURL + endpoint)
+	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
@@ -79,7 +126,7 @@ func (c *Client) get(endpoint string, params interface{}) (*http.Response, error
 		parsedURL.RawQuery = uv.Encode()
 	}
 
-	req, err := http.NewRequest(http.MethodGet, parsedURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GET request: %w", err)
 	}
@@ -93,16 +140,22 @@ func (c *Client) get(endpoint string, params interface{}) (*http.Response, error
 	return resp, nil
 }
 
-// postRaw makes a POST request to the specified URL with the given parameters.
+// post makes a POST request to the specified URL with the given parameters.
 // It returns the response as is without parsing or any error encountered.
 // The caller is responsible for closing the response body.
-func (c *Client) post(endpoint string, params interface{}) (*http.Response, error) {
+func (c *Client) post(ctx context.Context, endpoint string, params interface{}) (*http.Response, error) {
+	return c.postURL(ctx, c.apiURL+endpoint, params)
+}
+
+// postURL makes a POST request to the specified absolute URL with the given parameters.
+// The caller is responsible for closing the response body.
+func (c *Client) postURL(ctx context.Context, rawURL string, params interface{}) (*http.Response, error) {
 	body, err := json.Marshal(params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal POST params: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.apiURL+endpoint, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create POST request: %w", err)
 	}
@@ -118,58 +171,143 @@ func (c *Client) post(endpoint string, params interface{}) (*http.Response, erro
 }
 
 // parseResponse parses the response body into the given response structure.
-func (c *Client) parseResponse(resp *http.Response) (json.RawMessage, error) {
+// The start is the time the request was sent, it's used to measure the request latency.
+func (c *Client) parseResponse(resp *http.Response, start time.Time) (json.RawMessage, ResponseMeta, error) {
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, ResponseMeta{}, err
+	}
+	fetchedAt := c.now()
+
+	var response Response
+	if err := c.decode(body, &response, ""); err != nil {
+		return nil, ResponseMeta{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Data, ResponseMeta{
+		TimeTaken:   response.TimeTaken,
+		ContextSlot: response.ContextSlot,
+		FetchedAt:   fetchedAt,
+		Latency:     fetchedAt.Sub(start),
+	}, nil
+}
+
+// readBody reads and closes the response body.
+// It returns an error if the response status code is not 200 OK.
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var response Response
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return response.Data, nil
+	return body, nil
 }
 
-// Quote returns a quote for a given input mint, output mint and amount
+// decode decodes the JSON data into v.
+// If strict decoding is enabled, the data is checked against the schema of v first,
+// every drift is reported to the drift handler, and if the client is configured
+// to fail on drift, a *SchemaDriftError is returned.
+func (c *Client) decode(data []byte, v interface{}, path string) error {
+	if c.strictDecoding {
+		drifts := checkSchema(data, reflect.TypeOf(v), path)
+		if c.driftHandler != nil {
+			for _, d := range drifts {
+				c.driftHandler(d)
+			}
+		}
+		if c.failOnDrift && len(drifts) > 0 {
+			return &SchemaDriftError{Drifts: drifts}
+		}
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// Quote returns a quote for a given input mint, output mint and amount.
+// Every returned route carries the response metadata, see Route.Meta.
 func (c *Client) Quote(params QuoteParams) (QuoteResponse, error) {
-	resp, err := c.get(c.endpointQuote, params)
+	return c.quote(context.Background(), params)
+}
+
+// quote returns a quote for the params, the request is bound to ctx.
+func (c *Client) quote(ctx context.Context, params QuoteParams) (QuoteResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quote params: %w", err)
+	}
+
+	start := c.now()
+	resp, err := c.get(ctx, c.endpointQuote, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make quote request: %w", err)
 	}
 
-	data, err := c.parseResponse(resp)
+	data, meta, err := c.parseResponse(resp, start)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse quote response: %w", err)
 	}
 
 	var quotes QuoteResponse
-	if err := json.Unmarshal(data, &quotes); err != nil {
+	if err := c.decode(data, &quotes, "data"); err != nil {
 		return nil, fmt.Errorf("failed to parse quote response: %w", err)
 	}
 
 	if len(quotes) == 0 {
 		return nil, fmt.Errorf("no quotes returned")
 	}
+	if err := c.checkSlot(meta.ContextSlot, params.MinContextSlot); err != nil {
+		return nil, err
+	}
+	for i := range quotes {
+		quotes[i].meta = meta
+		quotes[i].params = &params
+	}
 
 	return quotes, nil
 }
 
 // Swap returns swap base64 serialized transaction for a route.
 // The caller is responsible for signing the transactions.
+// If the quote max age is set (see WithQuoteMaxAge) and the route is stale,
+// it's either re-quoted (see WithRequote) or ErrQuoteExpired is returned.
 func (c *Client) Swap(params SwapParams) (string, error) {
-	resp, err := c.post(c.endpointSwap, params)
+	return c.swap(context.Background(), params)
+}
+
+// swap returns swap base64 serialized transaction for a route, the requests are bound to ctx.
+func (c *Client) swap(ctx context.Context, params SwapParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", fmt.Errorf("invalid swap params: %w", err)
+	}
+
+	route, err := c.freshRoute(ctx, params.Route)
+	if err != nil {
+		return "", err
+	}
+	params.Route = route
+
+	return c.postSwap(ctx, params)
+}
+
+// postSwap requests the swap transaction for the validated params as is, the request is bound to ctx.
+func (c *Client) postSwap(ctx context.Context, params SwapParams) (string, error) {
+	resp, err := c.post(ctx, c.endpointSwap, params)
 	if err != nil {
 		return "", fmt.Errorf("failed to make swap request: %w", err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse swap response: %w", err)
 	}
 
 	var response SwapResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decode(body, &response, ""); err != nil {
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -177,21 +315,36 @@ func (c *Client) Swap(params SwapParams) (string, error) {
 }
 
 // Price returns simple price for a given input mint, output mint and amount.
+// Every returned price carries the response metadata, see Price.Meta.
 func (c *Client) Price(params PriceParams) (PriceMap, error) {
-	resp, err := c.get(c.endpointPrice, params)
+	return c.price(context.Background(), params)
+}
+
+// price returns simple price for the params, the request is bound to ctx.
+func (c *Client) price(ctx context.Context, params PriceParams) (PriceMap, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid price params: %w", err)
+	}
+
+	start := c.now()
+	resp, err := c.get(ctx, c.endpointPrice, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make price request: %w", err)
 	}
 
-	data, err := c.parseResponse(resp)
+	data, meta, err := c.parseResponse(resp, start)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse price response: %w", err)
 	}
 
 	var price PriceMap
-	if err := json.Unmarshal(data, &price); err != nil {
+	if err := c.decode(data, &price, "data"); err != nil {
 		return nil, fmt.Errorf("failed to parse price response: %w", err)
 	}
+	for id, p := range price {
+		p.meta = meta
+		price[id] = p
+	}
 
 	return price, nil
 }
@@ -199,68 +352,90 @@ func (c *Client) Price(params PriceParams) (PriceMap, error) {
 // RoutesMap returns a hash map, input mint as key and an array of valid output mint as values,
 // token mints are indexed to reduce the file size.
 func (c *Client) RoutesMap(onlyDirectRoutes bool) (IndexedRoutesMap, error) {
-	resp, err := c.get(c.endpointRoutesMap, url.Values{
+	resp, err := c.get(context.Background(), c.endpointRoutesMap, url.Values{
 		"onlyDirectRoutes": []string{strconv.FormatBool(onlyDirectRoutes)},
 	})
 	if err != nil {
 		return IndexedRoutesMap{}, fmt.Errorf("failed to make routes map request: %w", err)
 	}
 
+	body, err := c.readBody(resp)
+	if err != nil {
+		return IndexedRoutesMap{}, fmt.Errorf("failed to parse routes map response: %w", err)
+	}
+
 	var routesMap IndexedRoutesMap
-	if err := json.NewDecoder(resp.Body).Decode(&routesMap); err != nil {
+	if err := c.decode(body, &routesMap, ""); err != nil {
 		return IndexedRoutesMap{}, fmt.Errorf("failed to parse routes map response: %w", err)
 	}
 
 	return routesMap, nil
 }
 
-// BestSwap returns the ebase64 encoded transaction for the best swap route
+// BestSwap returns the base64 encoded transaction for the best swap route
 // for a given input mint, output mint and amount.
-// Default swap mode: ExactOut, so the amount is the amount of output token.
+// Default swap mode: ExactIn, so the amount is the amount of input token.
 // Default wrap unwrap sol: true
+// Default transaction version: legacy, the quote is requested with the matching flag.
+// If the best route trips a guardrail, ErrPriceImpactTooHigh or ErrOutputBelowMinimum is returned
+// instead of the transaction. A route re-quoted before the swap (see WithRequote) is checked too. Use PlanSwap to see the swap plan without building the transaction.
+// If the RPC endpoint is configured, the wallet balances are checked first, see Preflight.
 func (c *Client) BestSwap(params BestSwapParams) (string, error) {
-	if params.SwapMode == "" {
-		params.SwapMode = SwapModeExactIn
+	_, swap, err := c.bestSwap(context.Background(), params)
+	return swap, err
+}
+
+// bestSwap returns the swap plan and the base64 encoded transaction for the best swap route.
+func (c *Client) bestSwap(ctx context.Context, params BestSwapParams) (SwapPlan, string, error) {
+	if err := params.Validate(); err != nil {
+		return SwapPlan{}, "", fmt.Errorf("invalid best swap params: %w", err)
 	}
-	routes, err := c.Quote(QuoteParams{
-		InputMint:        params.InputMint,
-		OutputMint:       params.OutputMint,
-		Amount:           params.Amount,
-		FeeBps:           params.FeeAmount,
-		SwapMode:         params.SwapMode,
-		OnlyDirectRoutes: false,
-	})
+	params = params.withDefaults()
+
+	preflight := c.rpcEndpoint != ""
+	plan, err := c.planSwap(ctx, params, preflight)
 	if err != nil {
-		return "", err
+		return plan, "", err
 	}
 
-	route, err := routes.GetBestRoute()
-	if err != nil {
-		return "", err
+	if preflight {
+		if err := c.preflight(ctx, params, plan); err != nil {
+			return plan, "", err
+		}
 	}
 
-	swap, err := c.Swap(SwapParams{
-		Route:               route,
-		UserPublicKey:       params.UserPublicKey,
-		DestinationWallet:   params.DestinationPublicKey,
-		FeeAccount:          params.FeeAccount,
-		WrapUnwrapSol:       utils.Pointer(true),
-		AsLegacyTransaction: utils.Pointer(true),
-	})
+	// The preflight may take long enough for the route to go stale. It's re-quoted here rather than
+	// by swap, so the guardrails are checked against the route which is actually swapped.
+	if plan.Route.IsStale(c.quoteMaxAge, c.now()) {
+		if plan, err = c.replan(ctx, plan, params); err != nil {
+			return plan, "", err
+		}
+	}
+
+	swap, err := c.postSwap(ctx, params.swapParams(plan.Route))
 	if err != nil {
-		return "", err
+		return plan, "", err
 	}
 
-	return swap, nil
+	return plan, swap, nil
 }
 
 // ExchangeRate returns the exchange rate for a given input mint, output mint and amount.
-// Default swap mode: ExactOut, so the amount is the amount of output token.
+// Default swap mode: ExactIn, so the amount is the amount of input token.
+// Token decimals are taken from the params or resolved from the token registry, see WithTokenRegistry.
 func (c *Client) ExchangeRate(params ExchangeRateParams) (Rate, error) {
 	result := Rate{
-		InputMint:  params.InputMint,
-		OutputMint: params.OutputMint,
+		InputMint:      params.InputMint,
+		OutputMint:     params.OutputMint,
+		InputDecimals:  c.optionalDecimals(params.InputMint, params.InputDecimals),
+		OutputDecimals: c.optionalDecimals(params.OutputMint, params.OutputDecimals),
+		InputSymbol:    tokenSymbol(c.tokens, params.InputMint.String()),
+		OutputSymbol:   tokenSymbol(c.tokens, params.OutputMint.String()),
 	}
+	if err := params.Validate(); err != nil {
+		return result, fmt.Errorf("invalid exchange rate params: %w", err)
+	}
+
 	routes, err := c.Quote(QuoteParams{
 		InputMint:        params.InputMint,
 		OutputMint:       params.OutputMint,
@@ -3,6 +3,7 @@ package jupiter
 import (
 	"net/http"
 	"strings"
+	"time"
 )
 
 // WithHTTPClient returns a ClientOption that configures the HTTP client used by the Jupiter client.
@@ -46,3 +47,86 @@ func WithEndpointRoutesMap(endpointRoutesMap string) ClientOption {
 		c.endpointRoutesMap = endpointRoutesMap
 	}
 }
+
+// WithTokenListURL returns a ClientOption that configures the token list URL used by the Jupiter client.
+func WithTokenListURL(tokenListURL string) ClientOption {
+	return func(c *Client) {
+		c.tokenListURL = tokenListURL
+	}
+}
+
+// WithTokenRegistry returns a ClientOption that configures the token registry used by the Jupiter client
+// to resolve token decimals, e.g. NewTokenMap of the tokens returned by TokenList.
+func WithTokenRegistry(tokens TokenRegistry) ClientOption {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithRPCEndpoint returns a ClientOption that configures the Solana JSON-RPC endpoint used by the Jupiter client
+// to fetch wallet balances. If set, BestSwap checks the wallet balances before building the transaction.
+func WithRPCEndpoint(rpcEndpoint string) ClientOption {
+	return func(c *Client) {
+		c.rpcEndpoint = rpcEndpoint
+	}
+}
+
+// WithStrictDecoding returns a ClientOption that enables strict decoding of API responses.
+// Every response is checked against the Go types it's decoded into, and every detected drift
+// (unknown fields, missing required fields, type changes) is reported to the given handler.
+// The handler may be nil if the client is configured to fail on drift.
+func WithStrictDecoding(handler DriftHandler) ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+		c.driftHandler = handler
+	}
+}
+
+// WithFailOnSchemaDrift returns a ClientOption that enables strict decoding
+// and makes the client return a *SchemaDriftError instead of the decoded response
+// when any drift is detected. It's meant to be used in CI to catch API changes early.
+func WithFailOnSchemaDrift() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+		c.failOnDrift = true
+	}
+}
+
+// WithClock returns a ClientOption that configures the clock used by the Jupiter client, e.g. for quote expiry
+// and fill timestamps. TWAP orders executed with the client's executors are scheduled by the same clock.
+// It's useful for testing. Default: system clock.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithQuoteMaxAge returns a ClientOption that configures the maximum age of a quote.
+// Swap refuses to build a transaction for a route older than maxAge with ErrQuoteExpired,
+// unless re-quoting is enabled with WithRequote.
+func WithQuoteMaxAge(maxAge time.Duration) ClientOption {
+	return func(c *Client) {
+		c.quoteMaxAge = maxAge
+	}
+}
+
+// WithMaxSlotLag returns a ClientOption that makes the Jupiter client reject quotes computed at a slot
+// more than maxLag slots behind the highest context slot seen in previous quote responses,
+// e.g. served by a lagging API node. Such quotes fail with ErrStaleSlot.
+func WithMaxSlotLag(maxLag int64) ClientOption {
+	return func(c *Client) {
+		c.maxSlotLag = maxLag
+	}
+}
+
+// WithRequote returns a ClientOption that makes Swap transparently re-quote stale routes
+// with the same quote params. The swap is aborted with ErrQuoteDeviation if the new route is worse
+// than the original one by more than toleranceBps basis points:
+// for ExactIn the output amount is compared, for ExactOut the input amount.
+// It has effect only together with WithQuoteMaxAge.
+func WithRequote(toleranceBps uint64) ClientOption {
+	return func(c *Client) {
+		c.requote = true
+		c.requoteToleranceBps = toleranceBps
+	}
+}
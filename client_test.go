@@ -9,9 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const (
-	wSolMint = "So11111111111111111111111111111111111111112"
-	usdcMint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+var (
+	wSolMint      = jupiter.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+	usdcMint      = jupiter.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	userPublicKey = jupiter.MustPublicKeyFromBase58("8HwPMNxtFDrvxXn1fJsAYB258TnA6Ydr1DWCtVYgRW4W")
 )
 
 func TestQuote(t *testing.T) {
@@ -30,8 +31,8 @@ func TestQuote(t *testing.T) {
 	quote := quotes[0]
 	// utils.PrettyPrint(quote)
 
-	assert.Equal(t, wSolMint, quote.MarketInfos[0].InputMint)
-	assert.Equal(t, usdcMint, quote.MarketInfos[0].OutputMint)
+	assert.Equal(t, wSolMint.String(), quote.MarketInfos[0].InputMint)
+	assert.Equal(t, usdcMint.String(), quote.MarketInfos[0].OutputMint)
 	assert.Equal(t, "100000", quote.Amount)
 }
 
@@ -59,7 +60,7 @@ func TestSwap(t *testing.T) {
 
 	t.Run("create swap tx", func(t *testing.T) {
 		swapTx, err := c.Swap(jupiter.SwapParams{
-			UserPublicKey: "8HwPMNxtFDrvxXn1fJsAYB258TnA6Ydr1DWCtVYgRW4W",
+			UserPublicKey: userPublicKey,
 			Route:         route,
 			WrapUnwrapSol: utils.Pointer(true),
 		})
@@ -75,13 +76,13 @@ func TestPrice(t *testing.T) {
 
 	price, err := c.Price(jupiter.PriceParams{
 		IDs:     "SOL",
-		VsToken: usdcMint,
+		VsToken: usdcMint.String(),
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, price)
 	assert.Equal(t, "So11111111111111111111111111111111111111112", price["SOL"].ID)
 	assert.Equal(t, "SOL", price["SOL"].MintSymbol)
-	assert.Equal(t, usdcMint, price["SOL"].VsToken)
+	assert.Equal(t, usdcMint.String(), price["SOL"].VsToken)
 
 	// utils.PrettyPrint(price)
 }
@@ -92,7 +93,7 @@ func TestRoutesMap(t *testing.T) {
 	routesMap, err := c.RoutesMap(true)
 	require.NoError(t, err)
 	require.NotEmpty(t, routesMap)
-	assert.Greater(t, len(routesMap.GetRoutesForMint(usdcMint)), 0)
+	assert.Greater(t, len(routesMap.GetRoutesForMint(usdcMint.String())), 0)
 }
 
 func TestExchangeRate(t *testing.T) {
@@ -119,7 +120,7 @@ func TestBestSwap(t *testing.T) {
 
 	var amount uint64 = 100000
 	bestSwap, err := c.BestSwap(jupiter.BestSwapParams{
-		UserPublicKey: "8HwPMNxtFDrvxXn1fJsAYB258TnA6Ydr1DWCtVYgRW4W",
+		UserPublicKey: userPublicKey,
 		InputMint:     wSolMint,
 		OutputMint:    usdcMint,
 		Amount:        amount,
@@ -0,0 +1,177 @@
+package jupiter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+type (
+	// CostBreakdown is the total cost of a route: LP and platform fees aggregated per mint,
+	// and the network fee and deposits in lamports.
+	CostBreakdown struct {
+		LpFees             map[string]uint64 `json:"lpFees"`             // LP fees per mint, in base units
+		PlatformFees       map[string]uint64 `json:"platformFees"`       // platform fees per mint, in base units
+		SignatureFee       uint64            `json:"signatureFee"`       // lamports
+		OpenOrdersDeposits uint64            `json:"openOrdersDeposits"` // lamports
+		AtaDeposits        uint64            `json:"ataDeposits"`        // lamports
+	}
+
+	// QuotedCost is the cost breakdown converted into a single quote currency.
+	QuotedCost struct {
+		VsToken            string  `json:"vsToken"`            // mint of the quote currency
+		LpFees             float64 `json:"lpFees"`             // all LP fees
+		PlatformFees       float64 `json:"platformFees"`       // all platform fees
+		SignatureFee       float64 `json:"signatureFee"`       // network fee
+		OpenOrdersDeposits float64 `json:"openOrdersDeposits"` // open orders account deposits
+		AtaDeposits        float64 `json:"ataDeposits"`        // associated token account deposits
+		Total              float64 `json:"total"`              // sum of all the above
+	}
+)
+
+// CostBreakdown returns the cost breakdown of the route.
+// Network fee and deposits are known only if the quote was requested with the user public key.
+func (r Route) CostBreakdown() (CostBreakdown, error) {
+	cost := CostBreakdown{
+		LpFees:       make(map[string]uint64),
+		PlatformFees: make(map[string]uint64),
+	}
+
+	for _, mi := range r.MarketInfos {
+		if err := addFee(cost.LpFees, mi.LpFee); err != nil {
+			return CostBreakdown{}, fmt.Errorf("failed to parse %s LP fee: %w", mi.Label, err)
+		}
+		if err := addFee(cost.PlatformFees, mi.PlatformFee); err != nil {
+			return CostBreakdown{}, fmt.Errorf("failed to parse %s platform fee: %w", mi.Label, err)
+		}
+	}
+
+	if f := r.Fees; f != nil {
+		cost.SignatureFee = uint64(f.SignatureFee)
+		cost.OpenOrdersDeposits = sumLamports(f.OpenOrdersDeposits)
+		cost.AtaDeposits = sumLamports(f.AtaDeposits)
+	}
+
+	return cost, nil
+}
+
+// addFee adds the fee amount to the per mint totals, skipping zero fees.
+func addFee(totals map[string]uint64, fee *Fee) error {
+	if fee == nil {
+		return nil
+	}
+	amount, err := parseUint(fee.Amount)
+	if err != nil {
+		return err
+	}
+	if amount > 0 {
+		totals[fee.Mint] += amount
+	}
+	return nil
+}
+
+// TotalLamports returns the network fee and deposits in lamports.
+func (c CostBreakdown) TotalLamports() uint64 {
+	return c.SignatureFee + c.OpenOrdersDeposits + c.AtaDeposits
+}
+
+// Mints returns the sorted list of mints the cost is charged in, including wrapped SOL for lamports.
+func (c CostBreakdown) Mints() []string {
+	set := make(map[string]struct{})
+	for mint := range c.LpFees {
+		set[mint] = struct{}{}
+	}
+	for mint := range c.PlatformFees {
+		set[mint] = struct{}{}
+	}
+	if c.TotalLamports() > 0 {
+		set[WrappedSOLMint.String()] = struct{}{}
+	}
+
+	mints := make([]string, 0, len(set))
+	for mint := range set {
+		mints = append(mints, mint)
+	}
+	sort.Strings(mints)
+
+	return mints
+}
+
+// QuoteCost converts the cost breakdown into the given quote currency using the price endpoint.
+// Token decimals are resolved from the token registry, see WithTokenRegistry.
+func (c *Client) QuoteCost(cost CostBreakdown, vsToken Mint) (QuotedCost, error) {
+	result := QuotedCost{VsToken: vsToken.String()}
+
+	mints := cost.Mints()
+	if len(mints) == 0 {
+		return result, nil
+	}
+
+	prices, err := c.Price(PriceParams{IDs: strings.Join(mints, ","), VsToken: vsToken.String()})
+	if err != nil {
+		return result, err
+	}
+
+	// value converts the amount of the mint in base units into the quote currency.
+	value := func(mint string, amount uint64) (float64, error) {
+		price, ok := prices[mint]
+		if !ok {
+			return 0, fmt.Errorf("%w: no price for %s", ErrUnknownMint, mint)
+		}
+		decimals, err := c.decimals(mint)
+		if err != nil {
+			return 0, err
+		}
+		return float64(amount) / math.Pow10(int(decimals)) * price.Price, nil
+	}
+
+	for mint, amount := range cost.LpFees {
+		v, err := value(mint, amount)
+		if err != nil {
+			return result, err
+		}
+		result.LpFees += v
+	}
+	for mint, amount := range cost.PlatformFees {
+		v, err := value(mint, amount)
+		if err != nil {
+			return result, err
+		}
+		result.PlatformFees += v
+	}
+
+	sol := WrappedSOLMint.String()
+	for _, f := range []struct {
+		amount uint64
+		dst    *float64
+	}{
+		{cost.SignatureFee, &result.SignatureFee},
+		{cost.OpenOrdersDeposits, &result.OpenOrdersDeposits},
+		{cost.AtaDeposits, &result.AtaDeposits},
+	} {
+		if f.amount == 0 {
+			continue
+		}
+		if *f.dst, err = value(sol, f.amount); err != nil {
+			return result, err
+		}
+	}
+
+	result.Total = result.LpFees + result.PlatformFees + result.SignatureFee + result.OpenOrdersDeposits + result.AtaDeposits
+
+	return result, nil
+}
+
+// decimals returns the number of decimals of the token with the given mint.
+func (c *Client) decimals(mint string) (uint8, error) {
+	if mint == WrappedSOLMint.String() {
+		return 9, nil
+	}
+	if c.tokens != nil {
+		if token, ok := c.tokens.Token(mint); ok {
+			return token.Decimals, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: decimals of %s are unknown", ErrUnknownMint, mint)
+}
@@ -0,0 +1,57 @@
+package jupiter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostBreakdown(t *testing.T) {
+	var ids string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/price": func(w http.ResponseWriter, r *http.Request) {
+			ids = r.URL.Query().Get("ids")
+			w.Header().Set("Content-Type", jupiter.ContentTypeJSON)
+			_, _ = w.Write([]byte(`{"data":{"So11111111111111111111111111111111111111112":{"id":"So11111111111111111111111111111111111111112","mintSymbol":"SOL","vsToken":"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v","vsTokenSymbol":"USDC","price":20}},"timeTaken":0.001}`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	quote, err := c.Quote(jupiter.QuoteParams{
+		InputMint:  wSolMint,
+		OutputMint: usdcMint,
+		Amount:     100000,
+	})
+	require.NoError(t, err)
+
+	cost, err := quote[0].CostBreakdown()
+	require.NoError(t, err)
+	assert.Equal(t, jupiter.CostBreakdown{
+		LpFees:       map[string]uint64{wSolMint.String(): 30},
+		PlatformFees: map[string]uint64{},
+		SignatureFee: 5000,
+		AtaDeposits:  2039280,
+	}, cost)
+	assert.Equal(t, uint64(2044280), cost.TotalLamports())
+	assert.Equal(t, []string{wSolMint.String()}, cost.Mints())
+
+	quoted, err := c.QuoteCost(cost, usdcMint)
+	require.NoError(t, err)
+	assert.Equal(t, wSolMint.String(), ids)
+	assert.Equal(t, usdcMint.String(), quoted.VsToken)
+	assert.InDelta(t, 0.0000006, quoted.LpFees, 1e-12)
+	assert.InDelta(t, 0.0001, quoted.SignatureFee, 1e-12)
+	assert.InDelta(t, 0.0407856, quoted.AtaDeposits, 1e-12)
+	assert.InDelta(t, 0.0408862, quoted.Total, 1e-12)
+
+	t.Run("missing price", func(t *testing.T) {
+		cost := jupiter.CostBreakdown{LpFees: map[string]uint64{usdcMint.String(): 10}}
+
+		_, err := c.QuoteCost(cost, wSolMint)
+		require.ErrorIs(t, err, jupiter.ErrUnknownMint)
+	})
+}
@@ -0,0 +1,216 @@
+package jupiter
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+)
+
+// Depth curve ladder scales.
+const (
+	DepthScaleLinear    = "linear"    // amounts are evenly spaced
+	DepthScaleGeometric = "geometric" // each amount is the previous one multiplied by a constant ratio
+)
+
+const (
+	defaultDepthSteps    = 10
+	defaultConcurrency   = 4     // default maximum number of concurrent quotes
+	depthSearchPrecision = 0.001 // relative precision of the max amount search
+)
+
+type (
+	// DepthParams are the parameters of the liquidity depth curve of the pair.
+	DepthParams struct {
+		InputMint        Mint   // input token mint
+		OutputMint       Mint   // output token mint
+		MinAmount        uint64 // smallest amount of input token
+		MaxAmount        uint64 // largest amount of input token
+		Steps            int    // number of amounts in the ladder, default: 10
+		Scale            string // ladder scale, default: linear (Available: linear, geometric)
+		OnlyDirectRoutes bool   // only use direct routes (no hoppings and split trade)
+		Concurrency      int    // maximum number of concurrent quotes, default: 4
+		InputDecimals    *uint8 // input token decimals, overrides the token registry (optional)
+		OutputDecimals   *uint8 // output token decimals, overrides the token registry (optional)
+	}
+
+	// DepthCurve is the best route output of the pair at a ladder of input amounts.
+	DepthCurve struct {
+		InputMint      Mint         `json:"inputMint"`                // input token mint
+		OutputMint     Mint         `json:"outputMint"`               // output token mint
+		InputDecimals  *uint8       `json:"inputDecimals,omitempty"`  // input token decimals
+		OutputDecimals *uint8       `json:"outputDecimals,omitempty"` // output token decimals
+		Points         []DepthPoint `json:"points"`                   // points in ascending order of the amount
+	}
+
+	// DepthPoint is the best route output for a single input amount.
+	DepthPoint struct {
+		InAmount       uint64   `json:"inAmount"`       // amount of input token
+		OutAmount      uint64   `json:"outAmount"`      // amount of output token of the best route
+		Price          *big.Rat `json:"price"`          // effective amount of output token per one input token, nil if decimals are unknown
+		PriceImpactPct float64  `json:"priceImpactPct"` // price impact of the best route as a fraction
+	}
+)
+
+// DepthCurve quotes the pair with ExactIn at a ladder of input amounts from MinAmount to MaxAmount concurrently
+// and returns the effective price and price impact at each amount.
+// Token decimals are taken from the params or resolved from the token registry, see WithTokenRegistry.
+func (c *Client) DepthCurve(params DepthParams) (DepthCurve, error) {
+	params = params.withDefaults()
+	if err := params.Validate(); err != nil {
+		return DepthCurve{}, fmt.Errorf("invalid depth params: %w", err)
+	}
+
+	curve := DepthCurve{
+		InputMint:      params.InputMint,
+		OutputMint:     params.OutputMint,
+		InputDecimals:  c.optionalDecimals(params.InputMint, params.InputDecimals),
+		OutputDecimals: c.optionalDecimals(params.OutputMint, params.OutputDecimals),
+	}
+
+	amounts := params.ladder()
+	curve.Points = make([]DepthPoint, len(amounts))
+	errs := make([]error, len(amounts))
+
+	sem := make(chan struct{}, params.Concurrency)
+	var wg sync.WaitGroup
+	wg.Add(len(amounts))
+	for i, amount := range amounts {
+		go func(i int, amount uint64) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			curve.Points[i], errs[i] = c.depthPoint(params, curve, amount)
+		}(i, amount)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return curve, fmt.Errorf("failed to quote amount %d: %w", amounts[i], err)
+		}
+	}
+
+	return curve, nil
+}
+
+// MaxAmountUnderImpact returns the point with the largest input amount between MinAmount and MaxAmount
+// whose best route price impact doesn't exceed maxPriceImpactPct, found with binary search to 0.1% precision.
+// The price impact is assumed to grow with the amount. Steps, Scale and Concurrency are ignored.
+// If the price impact of MinAmount is already too high, ErrPriceImpactTooHigh is returned.
+func (c *Client) MaxAmountUnderImpact(params DepthParams, maxPriceImpactPct float64) (DepthPoint, error) {
+	params = params.withDefaults()
+	if err := params.Validate(); err != nil {
+		return DepthPoint{}, fmt.Errorf("invalid depth params: %w", err)
+	}
+
+	curve := DepthCurve{
+		InputDecimals:  c.optionalDecimals(params.InputMint, params.InputDecimals),
+		OutputDecimals: c.optionalDecimals(params.OutputMint, params.OutputDecimals),
+	}
+
+	best, err := c.depthPoint(params, curve, params.MinAmount)
+	if err != nil {
+		return DepthPoint{}, fmt.Errorf("failed to quote amount %d: %w", params.MinAmount, err)
+	}
+	if best.PriceImpactPct > maxPriceImpactPct {
+		return best, fmt.Errorf("%w: %s at the minimum amount %d", ErrPriceImpactTooHigh, formatPct(best.PriceImpactPct), params.MinAmount)
+	}
+
+	top, err := c.depthPoint(params, curve, params.MaxAmount)
+	if err != nil {
+		return best, fmt.Errorf("failed to quote amount %d: %w", params.MaxAmount, err)
+	}
+	if top.PriceImpactPct <= maxPriceImpactPct {
+		return top, nil
+	}
+
+	// The price impact is acceptable at lo and too high at hi.
+	lo, hi := params.MinAmount, params.MaxAmount
+	for float64(hi-lo) > float64(hi)*depthSearchPrecision && hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		point, err := c.depthPoint(params, curve, mid)
+		if err != nil {
+			return best, fmt.Errorf("failed to quote amount %d: %w", mid, err)
+		}
+		if point.PriceImpactPct <= maxPriceImpactPct {
+			lo, best = mid, point
+		} else {
+			hi = mid
+		}
+	}
+
+	return best, nil
+}
+
+// depthPoint quotes the amount and returns the point of the best route.
+func (c *Client) depthPoint(params DepthParams, curve DepthCurve, amount uint64) (DepthPoint, error) {
+	routes, err := c.Quote(QuoteParams{
+		InputMint:        params.InputMint,
+		OutputMint:       params.OutputMint,
+		Amount:           amount,
+		SwapMode:         SwapModeExactIn,
+		OnlyDirectRoutes: params.OnlyDirectRoutes,
+	})
+	if err != nil {
+		return DepthPoint{}, err
+	}
+
+	route, err := routes.GetBestRoute()
+	if err != nil {
+		return DepthPoint{}, err
+	}
+
+	point := DepthPoint{PriceImpactPct: route.PriceImpactPct}
+	if point.InAmount, err = parseUint(route.InAmount); err != nil {
+		return DepthPoint{}, fmt.Errorf("failed to parse in amount: %w", err)
+	}
+	if point.OutAmount, err = parseUint(route.OutAmount); err != nil {
+		return DepthPoint{}, fmt.Errorf("failed to parse out amount: %w", err)
+	}
+
+	point.Price = Rate{
+		InAmount:       point.InAmount,
+		OutAmount:      point.OutAmount,
+		InputDecimals:  curve.InputDecimals,
+		OutputDecimals: curve.OutputDecimals,
+	}.Price()
+
+	return point, nil
+}
+
+// withDefaults returns a copy of the params with defaults applied.
+func (p DepthParams) withDefaults() DepthParams {
+	if p.Steps == 0 {
+		p.Steps = defaultDepthSteps
+	}
+	if p.Scale == "" {
+		p.Scale = DepthScaleLinear
+	}
+	if p.Concurrency <= 0 {
+		p.Concurrency = defaultConcurrency
+	}
+	return p
+}
+
+// ladder returns the input amounts of the depth curve in ascending order, without duplicates.
+func (p DepthParams) ladder() []uint64 {
+	amounts := make([]uint64, 0, p.Steps)
+	for i := 0; i < p.Steps; i++ {
+		var amount uint64
+		switch {
+		case i == p.Steps-1:
+			amount = p.MaxAmount
+		case p.Scale == DepthScaleGeometric:
+			ratio := float64(p.MaxAmount) / float64(p.MinAmount)
+			amount = uint64(math.Round(float64(p.MinAmount) * math.Pow(ratio, float64(i)/float64(p.Steps-1))))
+		default:
+			amount = p.MinAmount + uint64(float64(p.MaxAmount-p.MinAmount)*float64(i)/float64(p.Steps-1))
+		}
+		if n := len(amounts); n > 0 && amounts[n-1] >= amount {
+			continue
+		}
+		amounts = append(amounts, amount)
+	}
+	return amounts
+}
@@ -0,0 +1,142 @@
+package jupiter_test
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDepthServer returns a quote server with the price impact growing linearly with the amount:
+// 1% per 100000 of input token.
+func newDepthServer(t *testing.T) (string, func() []uint64) {
+	var mu sync.Mutex
+	var amounts []uint64
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			amount, err := strconv.ParseUint(r.URL.Query().Get("amount"), 10, 64)
+			if err != nil {
+				t.Errorf("invalid amount: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			amounts = append(amounts, amount)
+			mu.Unlock()
+
+			impact := float64(amount) / 1e7
+			out := uint64(float64(amount) * 20 * (1 - impact))
+			w.Header().Set("Content-Type", jupiter.ContentTypeJSON)
+			fmt.Fprintf(w, `{"data":[{"inAmount":"%d","outAmount":"%d","priceImpactPct":%g,"swapMode":"ExactIn","marketInfos":[]}]}`, amount, out, impact)
+		},
+	})
+
+	return srv.URL, func() []uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		sorted := append([]uint64(nil), amounts...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		amounts = nil
+		return sorted
+	}
+}
+
+func TestDepthCurve(t *testing.T) {
+	apiURL, requested := newDepthServer(t)
+	c := jupiter.NewClient(jupiter.WithAPIURL(apiURL))
+
+	params := jupiter.DepthParams{
+		InputMint:      wSolMint,
+		OutputMint:     usdcMint,
+		MinAmount:      1000,
+		MaxAmount:      1000000,
+		Steps:          4,
+		OutputDecimals: utils.Pointer[uint8](9),
+	}
+
+	t.Run("linear", func(t *testing.T) {
+		curve, err := c.DepthCurve(params)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1000, 334000, 667000, 1000000}, requested())
+
+		require.Len(t, curve.Points, 4)
+		assert.Equal(t, uint64(1000), curve.Points[0].InAmount)
+		assert.Equal(t, uint64(19998), curve.Points[0].OutAmount)
+		assert.Equal(t, big.NewRat(19998, 1000), curve.Points[0].Price)
+		assert.InDelta(t, 0.0001, curve.Points[0].PriceImpactPct, 1e-12)
+		assert.InDelta(t, 0.1, curve.Points[3].PriceImpactPct, 1e-12)
+		assert.Equal(t, big.NewRat(18, 1), curve.Points[3].Price)
+	})
+
+	t.Run("geometric", func(t *testing.T) {
+		params := params
+		params.Scale = jupiter.DepthScaleGeometric
+		params.Concurrency = 1
+
+		curve, err := c.DepthCurve(params)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1000, 10000, 100000, 1000000}, requested())
+		require.Len(t, curve.Points, 4)
+	})
+
+	t.Run("unknown decimals", func(t *testing.T) {
+		params := params
+		params.OutputDecimals = nil
+
+		curve, err := c.DepthCurve(params)
+		require.NoError(t, err)
+		requested()
+		assert.Nil(t, curve.Points[0].Price)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		params := params
+		params.MaxAmount = params.MinAmount
+		params.Scale = "log"
+
+		_, err := c.DepthCurve(params)
+		var errs jupiter.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Len(t, errs, 2)
+	})
+}
+
+func TestMaxAmountUnderImpact(t *testing.T) {
+	apiURL, requested := newDepthServer(t)
+	c := jupiter.NewClient(jupiter.WithAPIURL(apiURL))
+
+	params := jupiter.DepthParams{
+		InputMint:  wSolMint,
+		OutputMint: usdcMint,
+		MinAmount:  1000,
+		MaxAmount:  1000000,
+	}
+
+	point, err := c.MaxAmountUnderImpact(params, 0.01)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, point.InAmount, uint64(100000))
+	assert.InDelta(t, 100000, point.InAmount, 100)
+	assert.LessOrEqual(t, point.PriceImpactPct, 0.01)
+	assert.Less(t, len(requested()), 20)
+
+	t.Run("max amount", func(t *testing.T) {
+		point, err := c.MaxAmountUnderImpact(params, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000000), point.InAmount)
+		assert.Equal(t, []uint64{1000, 1000000}, requested())
+	})
+
+	t.Run("min amount", func(t *testing.T) {
+		_, err := c.MaxAmountUnderImpact(params, 0.00001)
+		require.ErrorIs(t, err, jupiter.ErrPriceImpactTooHigh)
+	})
+}
@@ -0,0 +1,212 @@
+package jupiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaDrift is returned when strict decoding is configured to fail on API schema drift.
+var ErrSchemaDrift = errors.New("api schema drift")
+
+// DriftKind is a kind of API schema drift.
+type DriftKind string
+
+// Predefined drift kinds.
+const (
+	DriftUnknownField DriftKind = "unknown_field" // the response contains a field which is not declared in the Go type
+	DriftMissingField DriftKind = "missing_field" // a required field is missing in the response, see requiredTag
+	DriftTypeMismatch DriftKind = "type_mismatch" // a field has a different JSON type than expected
+)
+
+type (
+	// SchemaDrift describes a single difference between an API response and the Go type it's decoded into.
+	SchemaDrift struct {
+		Kind   DriftKind // kind of the drift
+		Type   string    // name of the Go type containing the field, e.g. Route
+		Path   string    // JSON path of the field, e.g. data[0].marketInfos[1].label
+		Detail string    // human-readable details
+	}
+
+	// DriftHandler is called for every detected schema drift, e.g. to log it or to increment a metric.
+	DriftHandler func(SchemaDrift)
+
+	// SchemaDriftError is returned when strict decoding is configured to fail on drift.
+	SchemaDriftError struct {
+		Drifts []SchemaDrift
+	}
+)
+
+// String returns a human-readable representation of the drift.
+func (d SchemaDrift) String() string {
+	s := fmt.Sprintf("%s at %s", d.Kind, d.Path)
+	if d.Type != "" {
+		s += " (" + d.Type + ")"
+	}
+	if d.Detail != "" {
+		s += ": " + d.Detail
+	}
+	return s
+}
+
+// Error implements the error interface.
+func (e *SchemaDriftError) Error() string {
+	msgs := make([]string, 0, len(e.Drifts))
+	for _, d := range e.Drifts {
+		msgs = append(msgs, d.String())
+	}
+	return ErrSchemaDrift.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether the error matches ErrSchemaDrift.
+func (e *SchemaDriftError) Is(target error) bool {
+	return target == ErrSchemaDrift
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// requiredTag marks the struct fields the API always returns, e.g. `json:"id" drift:"required"`.
+// Only missing required fields are reported as drift, other fields may be left out by the API.
+const requiredTag = "required"
+
+// checkSchema compares the raw JSON against the given Go type and returns all detected drifts.
+func checkSchema(raw json.RawMessage, typ reflect.Type, path string) []SchemaDrift {
+	var drifts []SchemaDrift
+	walkSchema(raw, typ, path, "", &drifts)
+	return drifts
+}
+
+// walkSchema recursively walks the raw JSON along with the Go type.
+func walkSchema(raw json.RawMessage, typ reflect.Type, path, owner string, drifts *[]SchemaDrift) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == rawMessageType {
+		return
+	}
+
+	mismatch := func() {
+		*drifts = append(*drifts, SchemaDrift{
+			Kind:   DriftTypeMismatch,
+			Type:   owner,
+			Path:   path,
+			Detail: fmt.Sprintf("expected %s, got %s", typ.Kind(), jsonKind(raw)),
+		})
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			mismatch()
+			return
+		}
+
+		fields := jsonFields(typ)
+		names := make([]string, 0, len(obj)+len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		for name := range obj {
+			if _, ok := fields[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fieldPath := joinPath(path, name)
+			val, present := obj[name]
+			sf, known := fields[name]
+			switch {
+			case !known:
+				*drifts = append(*drifts, SchemaDrift{
+					Kind:   DriftUnknownField,
+					Type:   typ.Name(),
+					Path:   fieldPath,
+					Detail: "value: " + truncate(string(val), 64),
+				})
+			case !present:
+				if sf.Tag.Get("drift") == requiredTag {
+					*drifts = append(*drifts, SchemaDrift{
+						Kind: DriftMissingField,
+						Type: typ.Name(),
+						Path: fieldPath,
+					})
+				}
+			default:
+				walkSchema(val, sf.Type, fieldPath, typ.Name(), drifts)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		var arr []json.RawMessage
+		if err := json.Unmarshal(raw, &arr); err != nil {
+			mismatch()
+			return
+		}
+		for i, val := range arr {
+			walkSchema(val, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), owner, drifts)
+		}
+
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			mismatch()
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walkSchema(obj[key], typ.Elem(), joinPath(path, key), owner, drifts)
+		}
+
+	default:
+		if err := json.Unmarshal(raw, reflect.New(typ).Interface()); err != nil {
+			mismatch()
+		}
+	}
+}
+
+// jsonKind returns the JSON type name of the raw value.
+func jsonKind(raw json.RawMessage) string {
+	switch raw[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	default:
+		return "number"
+	}
+}
+
+// joinPath joins JSON path segments.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// truncate truncates the string to the given length.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
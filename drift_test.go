@@ -0,0 +1,73 @@
+package jupiter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/price": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"SOL":{"id":"So11111111111111111111111111111111111111112","mintSymbol":"SOL","vsToken":"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v","price":"21.1"}},"timeTaken":0.001}`))
+		},
+		"/price/valid": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"SOL":{"id":"So11111111111111111111111111111111111111112","mintSymbol":"SOL","vsToken":"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v","vsTokenSymbol":"USDC","price":21.1}},"timeTaken":0.001}`))
+		},
+	})
+	quoteParams := jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100000}
+
+	t.Run("report drift", func(t *testing.T) {
+		var drifts []jupiter.SchemaDrift
+		c := jupiter.NewClient(
+			jupiter.WithAPIURL(srv.URL),
+			jupiter.WithStrictDecoding(func(d jupiter.SchemaDrift) { drifts = append(drifts, d) }),
+		)
+
+		quotes, err := c.Quote(quoteParams)
+		require.NoError(t, err)
+		require.Len(t, quotes, 2)
+
+		assert.Equal(t, []jupiter.SchemaDrift{
+			{Kind: jupiter.DriftUnknownField, Type: "MarketInfo", Path: "data[0].marketInfos[0].ammVersion", Detail: "value: 2"},
+			{Kind: jupiter.DriftUnknownField, Type: "Route", Path: "data[0].routeVersion", Detail: "value: 3"},
+		}, drifts)
+
+		drifts = nil
+		_, err = c.Price(jupiter.PriceParams{IDs: "SOL"})
+		require.Error(t, err)
+		assert.Equal(t, []jupiter.SchemaDrift{
+			{Kind: jupiter.DriftTypeMismatch, Type: "Price", Path: "data.SOL.price", Detail: "expected float64, got string"},
+			{Kind: jupiter.DriftMissingField, Type: "Price", Path: "data.SOL.vsTokenSymbol"},
+		}, drifts)
+	})
+
+	t.Run("fail on drift", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithFailOnSchemaDrift())
+
+		_, err := c.Quote(quoteParams)
+		require.ErrorIs(t, err, jupiter.ErrSchemaDrift)
+		assert.Contains(t, err.Error(), "data[0].routeVersion")
+
+		// Optional fields, e.g. the context slot of the price response, are not a drift.
+		c = jupiter.NewClient(
+			jupiter.WithAPIURL(srv.URL),
+			jupiter.WithEndpointPrice("/price/valid"),
+			jupiter.WithFailOnSchemaDrift(),
+		)
+		price, err := c.Price(jupiter.PriceParams{IDs: "SOL"})
+		require.NoError(t, err)
+		assert.Equal(t, 21.1, price["SOL"].Price)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		_, err := c.Quote(quoteParams)
+		require.NoError(t, err)
+	})
+}
@@ -1,136 +1,286 @@
 package jupiter
 
 import (
+	"encoding/json"
+	"reflect"
 	"strconv"
+	"time"
+
+	"github.com/dmitrymomot/jupiter/utils"
 )
 
 // MarketInfo is a market info object structure.
 type MarketInfo struct {
-	ID                 string  `json:"id"`
-	Label              string  `json:"label"`
-	InputMint          string  `json:"inputMint"`
-	OutputMint         string  `json:"outputMint"`
-	NotEnoughLiquidity bool    `json:"notEnoughLiquidity"`
-	InAmount           string  `json:"inAmount"`
-	OutAmount          string  `json:"outAmount"`
+	ID                 string  `json:"id" drift:"required"`
+	Label              string  `json:"label" drift:"required"`
+	InputMint          string  `json:"inputMint" drift:"required"`
+	OutputMint         string  `json:"outputMint" drift:"required"`
+	NotEnoughLiquidity bool    `json:"notEnoughLiquidity" drift:"required"`
+	InAmount           string  `json:"inAmount" drift:"required"`
+	OutAmount          string  `json:"outAmount" drift:"required"`
 	MinInAmount        string  `json:"minInAmount,omitempty"`
 	MinOutAmount       string  `json:"minOutAmount,omitempty"`
-	PriceImpactPct     float64 `json:"priceImpactPct"`
-	LpFee              *Fee    `json:"lpFee"`
-	PlatformFee        *Fee    `json:"platformFee"`
+	PriceImpactPct     float64 `json:"priceImpactPct" drift:"required"`
+	LpFee              *Fee    `json:"lpFee" drift:"required"`
+	PlatformFee        *Fee    `json:"platformFee" drift:"required"`
+
+	raw json.RawMessage // original JSON as returned by the API
+}
+
+// marketInfo is an alias to decode and encode MarketInfo without recursion.
+type marketInfo MarketInfo
+
+// UnmarshalJSON decodes the market info and keeps the original JSON.
+func (m *MarketInfo) UnmarshalJSON(data []byte) error {
+	var v marketInfo
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*m = MarketInfo(v)
+	m.raw = copyRaw(data)
+	return nil
+}
+
+// MarshalJSON returns the original JSON if the market info was decoded from the API response,
+// so fields unknown to this package are preserved.
+func (m MarketInfo) MarshalJSON() ([]byte, error) {
+	if len(m.raw) > 0 {
+		return m.raw, nil
+	}
+	return json.Marshal(marketInfo(m))
+}
+
+// Raw returns the original JSON of the market info, or nil if it was not decoded from JSON.
+func (m MarketInfo) Raw() json.RawMessage {
+	return m.raw
+}
+
+// UnknownFields returns the fields of the original JSON which are not declared in MarketInfo.
+func (m MarketInfo) UnknownFields() map[string]json.RawMessage {
+	return unknownFields(m.raw, reflect.TypeOf(marketInfo{}))
+}
+
+// ResponseMeta is the metadata of an API response.
+type ResponseMeta struct {
+	TimeTaken   float64       `json:"timeTaken"`   // server-side processing time in seconds, as reported by the API
+	ContextSlot int64         `json:"contextSlot"` // slot the response was computed at
+	FetchedAt   time.Time     `json:"fetchedAt"`   // local time the response was received
+	Latency     time.Duration `json:"latency"`     // round-trip time of the HTTP request, including reading the body
 }
 
 // Fee is a fee object structure.
 type Fee struct {
-	Amount string  `json:"amount"`
-	Mint   string  `json:"mint"`
-	Pct    float64 `json:"pct"`
+	Amount string  `json:"amount" drift:"required"`
+	Mint   string  `json:"mint" drift:"required"`
+	Pct    float64 `json:"pct" drift:"required"`
 }
 
 // Route is a route object structure.
 type Route struct {
-	InAmount             string       `json:"inAmount"`
-	OutAmount            string       `json:"outAmount"`
-	PriceImpactPct       float64      `json:"priceImpactPct"`
-	MarketInfos          []MarketInfo `json:"marketInfos"`
-	Amount               string       `json:"amount"`
-	SlippageBps          int64        `json:"slippageBps"`          // minimum: 0, maximum: 10000
-	OtherAmountThreshold string       `json:"otherAmountThreshold"` // The threshold for the swap based on the provided slippage: when swapMode is ExactIn the minimum out amount, when swapMode is ExactOut the maximum in amount
-	SwapMode             string       `json:"swapMode"`
-	Fees                 *struct {
-		SignatureFee             int64   `json:"signatureFee"`             // This inidicate the total amount needed for signing transaction(s). Value in lamports.
-		OpenOrdersDeposits       []int64 `json:"openOrdersDeposits"`       // This inidicate the total amount needed for deposit of serum order account(s). Value in lamports.
-		AtaDeposits              []int64 `json:"ataDeposits"`              // This inidicate the total amount needed for deposit of associative token account(s). Value in lamports.
-		TotalFeeAndDeposits      int64   `json:"totalFeeAndDeposits"`      // This indicate the total lamports needed for fees and deposits above.
-		MinimumSolForTransaction int64   `json:"minimumSOLForTransaction"` // This inidicate the minimum lamports needed for transaction(s). Might be used to create wrapped SOL and will be returned when the wrapped SOL is closed. Also ensures rent exemption of the wallet.
-	} `json:"fees,omitempty"`
+	InAmount             string       `json:"inAmount" drift:"required"`
+	OutAmount            string       `json:"outAmount" drift:"required"`
+	PriceImpactPct       float64      `json:"priceImpactPct" drift:"required"`
+	MarketInfos          []MarketInfo `json:"marketInfos" drift:"required"`
+	Amount               string       `json:"amount" drift:"required"`
+	SlippageBps          int64        `json:"slippageBps" drift:"required"`          // minimum: 0, maximum: 10000
+	OtherAmountThreshold string       `json:"otherAmountThreshold" drift:"required"` // The threshold for the swap based on the provided slippage: when swapMode is ExactIn the minimum out amount, when swapMode is ExactOut the maximum in amount
+	SwapMode             string       `json:"swapMode" drift:"required"`
+	Fees                 *RouteFees   `json:"fees,omitempty"`
+
+	raw    json.RawMessage // original JSON as returned by the API
+	meta   ResponseMeta    // metadata of the quote response the route was returned in
+	params *QuoteParams    // params of the quote request the route was returned for
+}
+
+// RouteFees are the fees and deposits needed for the route, all values are in lamports.
+// They're returned only if the user public key is passed to the quote request.
+type RouteFees struct {
+	SignatureFee             int64   `json:"signatureFee" drift:"required"`             // This inidicate the total amount needed for signing transaction(s). Value in lamports.
+	OpenOrdersDeposits       []int64 `json:"openOrdersDeposits" drift:"required"`       // This inidicate the total amount needed for deposit of serum order account(s). Value in lamports.
+	AtaDeposits              []int64 `json:"ataDeposits" drift:"required"`              // This inidicate the total amount needed for deposit of associative token account(s). Value in lamports.
+	TotalFeeAndDeposits      int64   `json:"totalFeeAndDeposits" drift:"required"`      // This indicate the total lamports needed for fees and deposits above.
+	MinimumSolForTransaction int64   `json:"minimumSOLForTransaction" drift:"required"` // This inidicate the minimum lamports needed for transaction(s). Might be used to create wrapped SOL and will be returned when the wrapped SOL is closed. Also ensures rent exemption of the wallet.
+}
+
+// route is an alias to decode and encode Route without recursion.
+type route Route
+
+// UnmarshalJSON decodes the route and keeps the original JSON,
+// so the route can be sent back to the swap endpoint verbatim.
+func (r *Route) UnmarshalJSON(data []byte) error {
+	var v route
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = Route(v)
+	r.raw = copyRaw(data)
+	return nil
+}
+
+// MarshalJSON returns the original JSON if the route was decoded from the API response,
+// so fields unknown to this package are not dropped before the route is posted to the swap endpoint.
+// Typed fields are meant for reading only: changes to them are not reflected in the encoded route.
+func (r Route) MarshalJSON() ([]byte, error) {
+	if len(r.raw) > 0 {
+		return r.raw, nil
+	}
+	return json.Marshal(route(r))
+}
+
+// Raw returns the original JSON of the route, or nil if it was not decoded from JSON.
+func (r Route) Raw() json.RawMessage {
+	return r.raw
+}
+
+// UnknownFields returns the fields of the original JSON which are not declared in Route.
+func (r Route) UnknownFields() map[string]json.RawMessage {
+	return unknownFields(r.raw, reflect.TypeOf(route{}))
+}
+
+// Meta returns the metadata of the quote response the route was returned in.
+func (r Route) Meta() ResponseMeta {
+	return r.meta
 }
 
 // Price is a price object structure.
 type Price struct {
-	ID            string  `json:"id"`            // Address of the token
-	MintSymbol    string  `json:"mintSymbol"`    // Symbol of the token
-	VsToken       string  `json:"vsToken"`       // Address of the token to compare against
-	VsTokenSymbol string  `json:"vsTokenSymbol"` // Symbol of the token to compare against
-	Price         float64 `json:"price"`         // Price of the token in relation to the vsToken. Default to 1 unit of the token worth in USDC if vsToken is not specified.
+	ID            string  `json:"id" drift:"required"`            // Address of the token
+	MintSymbol    string  `json:"mintSymbol" drift:"required"`    // Symbol of the token
+	VsToken       string  `json:"vsToken" drift:"required"`       // Address of the token to compare against
+	VsTokenSymbol string  `json:"vsTokenSymbol" drift:"required"` // Symbol of the token to compare against
+	Price         float64 `json:"price" drift:"required"`         // Price of the token in relation to the vsToken. Default to 1 unit of the token worth in USDC if vsToken is not specified.
+
+	meta ResponseMeta // metadata of the price response
+}
+
+// Meta returns the metadata of the price response the price was returned in.
+func (p Price) Meta() ResponseMeta {
+	return p.meta
 }
 
 // PriceMap is a price map objects structure.
 type PriceMap map[string]Price
 
+// Meta returns the metadata of the price response.
+func (m PriceMap) Meta() ResponseMeta {
+	for _, p := range m {
+		return p.meta
+	}
+	return ResponseMeta{}
+}
+
 // QuoteParams are the parameters for a quote request.
 type QuoteParams struct {
-	InputMint  string `url:"inputMint"`  // required
-	OutputMint string `url:"outputMint"` // required
+	InputMint  Mint   `url:"inputMint"`  // required
+	OutputMint Mint   `url:"outputMint"` // required
 	Amount     uint64 `url:"amount"`     // required
 
-	SwapMode            string `url:"swapMode,omitempty"` // Swap mode, default is ExactIn; Available values : ExactIn, ExactOut.
-	SlippageBps         uint64 `url:"slippageBps,omitempty"`
-	FeeBps              uint64 `url:"feeBps,omitempty"`              // Fee BPS (only pass in if you want to charge a fee on this swap)
-	OnlyDirectRoutes    bool   `url:"onlyDirectRoutes,omitempty"`    // Only return direct routes (no hoppings and split trade)
-	AsLegacyTransaction bool   `url:"asLegacyTransaction,omitempty"` // Only return routes that can be done in a single legacy transaction. (Routes might be limited)
-	UserPublicKey       string `url:"userPublicKey,omitempty"`       // Public key of the user (only pass in if you want deposit and fee being returned, might slow down query)
+	SwapMode            string    `url:"swapMode,omitempty"` // Swap mode, default is ExactIn; Available values : ExactIn, ExactOut.
+	SlippageBps         uint64    `url:"slippageBps,omitempty"`
+	FeeBps              uint64    `url:"feeBps,omitempty"`              // Fee BPS (only pass in if you want to charge a fee on this swap)
+	OnlyDirectRoutes    bool      `url:"onlyDirectRoutes,omitempty"`    // Only return direct routes (no hoppings and split trade)
+	AsLegacyTransaction bool      `url:"asLegacyTransaction,omitempty"` // Only return routes that can be done in a single legacy transaction. (Routes might be limited)
+	UserPublicKey       PublicKey `url:"userPublicKey,omitempty"`       // Public key of the user (only pass in if you want deposit and fee being returned, might slow down query)
+	Dexes               []string  `url:"dexes,comma,omitempty"`         // Only route through the DEXes with the given labels
+	ExcludeDexes        []string  `url:"excludeDexes,comma,omitempty"`  // Never route through the DEXes with the given labels
+	MinContextSlot      int64     `url:"-"`                             // Reject quotes computed at an older slot with ErrStaleSlot (client-side check)
 }
 
 // QuoteResponse is the response from a quote request.
 type QuoteResponse []Route
 
+// Meta returns the metadata of the quote response.
+func (q QuoteResponse) Meta() ResponseMeta {
+	if len(q) == 0 {
+		return ResponseMeta{}
+	}
+	return q[0].meta
+}
+
 // GetBestRoute returns the best route from a quote response.
 func (q QuoteResponse) GetBestRoute() (Route, error) {
+	i, err := q.bestRouteIndex(0)
+	if err != nil {
+		return Route{}, err
+	}
+	return q[i], nil
+}
+
+// GetBestRouteNetOfFees returns the best route from a quote response after subtracting
+// the route fees and deposits (Fees.TotalFeeAndDeposits) from its output for ExactIn,
+// or adding them to its input for ExactOut.
+// lamportValue is the value of one lamport in base units of the output token for ExactIn,
+// or of the input token for ExactOut, see Client.LamportValue.
+// Fees are returned only for quotes requested with the user public key.
+func (q QuoteResponse) GetBestRouteNetOfFees(lamportValue float64) (Route, error) {
+	i, err := q.bestRouteIndex(lamportValue)
+	if err != nil {
+		return Route{}, err
+	}
+	return q[i], nil
+}
+
+// bestRouteIndex returns the index of the best route in the quote response.
+// The best route gives the most output for ExactIn and takes the least input for ExactOut.
+// If lamportValue is positive, fees and deposits of each route are converted with it
+// into the compared token, see GetBestRouteNetOfFees.
+func (q QuoteResponse) bestRouteIndex(lamportValue float64) (int, error) {
 	if len(q) == 0 {
-		return Route{}, ErrNoRoute
+		return 0, ErrNoRoute
 	}
 	if len(q) == 1 {
-		return q[0], nil
-	}
-
-	bestRoute := q[0]
-	for _, route := range q {
-		if route.SwapMode == SwapModeExactIn {
-			amount, err := strconv.ParseFloat(route.OutAmount, 64)
-			if err != nil {
-				return Route{}, err
-			}
-			bestAmount, err := strconv.ParseFloat(bestRoute.OutAmount, 64)
-			if err != nil {
-				return Route{}, err
-			}
-			if amount > bestAmount {
-				bestRoute = route
-			}
-			continue
-		} else {
-			amount, err := strconv.ParseFloat(route.InAmount, 64)
-			if err != nil {
-				return Route{}, err
-			}
-			bestAmount, err := strconv.ParseFloat(bestRoute.InAmount, 64)
-			if err != nil {
-				return Route{}, err
-			}
-			if amount > bestAmount {
-				bestRoute = route
-			}
-			continue
+		return 0, nil
+	}
+
+	best, bestScore := 0, 0.0
+	for i, route := range q {
+		score, err := route.score(lamportValue)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 || score > bestScore {
+			best, bestScore = i, score
 		}
 	}
-	return bestRoute, nil
+	return best, nil
+}
+
+// score returns the route score to compare routes of the same quote, the higher the better.
+func (r Route) score(lamportValue float64) (float64, error) {
+	var fees float64
+	if r.Fees != nil && lamportValue > 0 {
+		fees = float64(r.Fees.TotalFeeAndDeposits) * lamportValue
+	}
+
+	if r.SwapMode == SwapModeExactOut {
+		amount, err := strconv.ParseFloat(r.InAmount, 64)
+		if err != nil {
+			return 0, err
+		}
+		return -(amount + fees), nil
+	}
+
+	amount, err := strconv.ParseFloat(r.OutAmount, 64)
+	if err != nil {
+		return 0, err
+	}
+	return amount - fees, nil
 }
 
 // SwapParams are the parameters for a swap request.
 type SwapParams struct {
-	Route                         Route  `json:"route"`                   // required
-	UserPublicKey                 string `json:"userPublicKey,omitempty"` // required
-	WrapUnwrapSol                 *bool  `json:"wrapUnwrapSOL,omitempty"`
-	FeeAccount                    string `json:"feeAccount,omitempty"`                    // Fee token account for the platform fee (only pass in if you set a feeBps), the mint is outputMint for the default swapMode.ExactOut and inputMint for swapMode.ExactIn.
-	AsLegacyTransaction           *bool  `json:"asLegacyTransaction,omitempty"`           // Request a legacy transaction rather than the default versioned transaction, needs to be paired with a quote using asLegacyTransaction otherwise the transaction might be too large.
-	ComputeUnitPriceMicroLamports *int64 `json:"computeUnitPriceMicroLamports,omitempty"` // Compute unit price to prioritize the transaction, the additional fee will be compute unit consumed * computeUnitPriceMicroLamports.
-	DestinationWallet             string `json:"destinationWallet,omitempty"`             // Public key of the wallet that will receive the output of the swap, this assumes the associated token account exists, currently adds a token transfer.
+	Route                         Route      `json:"route"`         // required
+	UserPublicKey                 PublicKey  `json:"userPublicKey"` // required
+	WrapUnwrapSol                 *bool      `json:"wrapUnwrapSOL,omitempty"`
+	FeeAccount                    *PublicKey `json:"feeAccount,omitempty"`                    // Fee token account for the platform fee (only pass in if you set a feeBps), the mint is outputMint for the default swapMode.ExactOut and inputMint for swapMode.ExactIn.
+	AsLegacyTransaction           *bool      `json:"asLegacyTransaction,omitempty"`           // Request a legacy transaction rather than the default versioned transaction, needs to be paired with a quote using asLegacyTransaction otherwise the transaction might be too large.
+	ComputeUnitPriceMicroLamports *int64     `json:"computeUnitPriceMicroLamports,omitempty"` // Compute unit price to prioritize the transaction, the additional fee will be compute unit consumed * computeUnitPriceMicroLamports.
+	DestinationWallet             *PublicKey `json:"destinationWallet,omitempty"`             // Public key of the wallet that will receive the output of the swap, this assumes the associated token account exists, currently adds a token transfer.
 }
 
 // SwapResponse is the response from a swap request.
 type SwapResponse struct {
-	SwapTransaction string `json:"swapTransaction"` // base64 encoded transaction string
+	SwapTransaction string `json:"swapTransaction" drift:"required"` // base64 encoded transaction string
 }
 
 // PriceParams are the parameters for a price request.
@@ -142,17 +292,19 @@ type PriceParams struct {
 
 // IndexedRoutesMap is a map of routes indexed by the route ID.
 type IndexedRoutesMap struct {
-	MintKeys        []string         `json:"mintKeys"`        // All the mints that are indexed to match in indexedRouteMap.
-	IndexedRouteMap map[string][]int `json:"indexedRouteMap"` // All the possible route and their corresponding output mints.
+	MintKeys        []string         `json:"mintKeys" drift:"required"`        // All the mints that are indexed to match in indexedRouteMap.
+	IndexedRouteMap map[string][]int `json:"indexedRouteMap" drift:"required"` // All the possible route and their corresponding output mints.
 }
 
 // GetRoutesForMint returns the routes for a given mint.
+// It scans all the mint keys, use Index for repeated lookups.
 func (r *IndexedRoutesMap) GetRoutesForMint(mint string) []string {
 	// Find index of mint in mintKeys.
 	var mintKeys []int
 	for key, val := range r.MintKeys {
 		if val == mint {
 			mintKeys = r.IndexedRouteMap[strconv.Itoa(key)]
+			break
 		}
 	}
 
@@ -167,28 +319,87 @@ func (r *IndexedRoutesMap) GetRoutesForMint(mint string) []string {
 
 // BestSwapParams contains the parameters for the best swap route.
 type BestSwapParams struct {
-	UserPublicKey        string // user base58 encoded public key
-	DestinationPublicKey string // destination base58 encoded public key (optional)
-	FeeAmount            uint64 // fee amount in token basis points (optional)
-	FeeAccount           string // fee token account for the platform fee (only pass in if you set a FeeAmount).
-	InputMint            string // input mint
-	OutputMint           string // output mint
-	Amount               uint64 // amount of output token
-	SwapMode             string // swap mode, default: ExactIn (Available: ExactIn, ExactOut)
+	UserPublicKey        PublicKey // user public key
+	DestinationPublicKey PublicKey // destination public key (optional)
+	FeeAmount            uint64    // fee amount in token basis points (optional)
+	FeeAccount           PublicKey // fee token account for the platform fee (only pass in if you set a FeeAmount).
+	InputMint            Mint      // input mint
+	OutputMint           Mint      // output mint
+	Amount               uint64    // amount of input token for ExactIn, amount of output token for ExactOut
+	SwapMode             string    // swap mode, default: ExactIn (Available: ExactIn, ExactOut)
+	SlippageBps          uint64    // slippage in basis points (optional, the API default is used if not set)
+	MaxPriceImpactPct    float64   // maximum price impact as a fraction, e.g. 0.01 for 1% (optional)
+	MinOutAmount         uint64    // minimum amount of output token received after slippage (optional)
+	NetOfFees            bool      // rank routes by amounts net of fees and deposits, requires token decimals (see WithTokenRegistry)
+	MinContextSlot       int64     // reject quotes computed at an older slot with ErrStaleSlot (optional)
+
+	OnlyDirectRoutes              bool   // only use direct routes (no hoppings and split trade)
+	WrapUnwrapSol                 *bool  // wrap and unwrap SOL automatically, default: true
+	AsLegacyTransaction           *bool  // build a legacy transaction instead of a versioned one, default: true
+	ComputeUnitPriceMicroLamports *int64 // compute unit price to prioritize the transaction (optional)
+}
+
+// withDefaults returns a copy of the params with defaults applied.
+func (p BestSwapParams) withDefaults() BestSwapParams {
+	if p.SwapMode == "" {
+		p.SwapMode = SwapModeExactIn
+	}
+	if p.WrapUnwrapSol == nil {
+		p.WrapUnwrapSol = utils.Pointer(true)
+	}
+	if p.AsLegacyTransaction == nil {
+		p.AsLegacyTransaction = utils.Pointer(true)
+	}
+	return p
+}
+
+// quoteParams returns the quote params matching the best swap params.
+func (p BestSwapParams) quoteParams() QuoteParams {
+	return QuoteParams{
+		InputMint:           p.InputMint,
+		OutputMint:          p.OutputMint,
+		Amount:              p.Amount,
+		FeeBps:              p.FeeAmount,
+		SwapMode:            p.SwapMode,
+		SlippageBps:         p.SlippageBps,
+		OnlyDirectRoutes:    p.OnlyDirectRoutes,
+		AsLegacyTransaction: p.AsLegacyTransaction != nil && *p.AsLegacyTransaction,
+		MinContextSlot:      p.MinContextSlot,
+	}
+}
+
+// swapParams returns the swap params for the given route matching the best swap params.
+func (p BestSwapParams) swapParams(route Route) SwapParams {
+	return SwapParams{
+		Route:                         route,
+		UserPublicKey:                 p.UserPublicKey,
+		DestinationWallet:             p.DestinationPublicKey.optional(),
+		FeeAccount:                    p.FeeAccount.optional(),
+		WrapUnwrapSol:                 p.WrapUnwrapSol,
+		AsLegacyTransaction:           p.AsLegacyTransaction,
+		ComputeUnitPriceMicroLamports: p.ComputeUnitPriceMicroLamports,
+	}
 }
 
 // ExchangeRateParams contains the parameters for the exchange rate request.
 type ExchangeRateParams struct {
-	InputMint  string // input token mint
-	OutputMint string // output token mint
-	Amount     uint64 // amount of token, depending on the swap mode
-	SwapMode   string // swap mode, default: ExactOut (Available: ExactIn, ExactOut)
+	InputMint      Mint   // input token mint
+	OutputMint     Mint   // output token mint
+	Amount         uint64 // amount of token, depending on the swap mode
+	SwapMode       string // swap mode, default: ExactIn (Available: ExactIn, ExactOut)
+	InputDecimals  *uint8 // input token decimals, overrides the token registry (optional)
+	OutputDecimals *uint8 // output token decimals, overrides the token registry (optional)
 }
 
-// ExchangeRate returns the exchange rate for a given input mint, output mint and amount.
+// Rate is the exchange rate for a given input mint, output mint and amount.
+// Token decimals and symbols are set if known, see WithTokenRegistry.
 type Rate struct {
-	InputMint  string `json:"inputMint"`  // input token mint
-	OutputMint string `json:"outputMint"` // output token mint
-	InAmount   uint64 `json:"inAmount"`   // amount of input token
-	OutAmount  uint64 `json:"outAmount"`  // amount of output token
+	InputMint      Mint   `json:"inputMint"`                // input token mint
+	OutputMint     Mint   `json:"outputMint"`               // output token mint
+	InAmount       uint64 `json:"inAmount"`                 // amount of input token
+	OutAmount      uint64 `json:"outAmount"`                // amount of output token
+	InputDecimals  *uint8 `json:"inputDecimals,omitempty"`  // input token decimals
+	OutputDecimals *uint8 `json:"outputDecimals,omitempty"` // output token decimals
+	InputSymbol    string `json:"inputSymbol,omitempty"`    // input token symbol
+	OutputSymbol   string `json:"outputSymbol,omitempty"`   // output token symbol
 }
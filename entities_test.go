@@ -0,0 +1,144 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a test server dispatching requests by URL path.
+func newTestServer(t *testing.T, handlers map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// serveFixture returns a handler responding with the content of the given fixture file.
+func serveFixture(fixture string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := os.ReadFile(fixture)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", jupiter.ContentTypeJSON)
+		_, _ = w.Write(b)
+	}
+}
+
+func TestRouteRoundTrip(t *testing.T) {
+	var swapBody []byte
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			swapBody, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+	})
+
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+	quotes, err := c.Quote(jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100000})
+	require.NoError(t, err)
+
+	route, err := quotes.GetBestRoute()
+	require.NoError(t, err)
+	assert.Equal(t, "2110", route.OutAmount)
+	assert.Equal(t, "Orca (Whirlpools)", route.MarketInfos[0].Label)
+	assert.JSONEq(t, "3", string(route.UnknownFields()["routeVersion"]))
+	assert.JSONEq(t, "2", string(route.MarketInfos[0].UnknownFields()["ammVersion"]))
+
+	tx, err := c.Swap(jupiter.SwapParams{Route: route, UserPublicKey: userPublicKey})
+	require.NoError(t, err)
+	assert.Equal(t, "dHg=", tx)
+
+	var posted struct {
+		Route json.RawMessage `json:"route"`
+	}
+	require.NoError(t, json.Unmarshal(swapBody, &posted))
+	assert.JSONEq(t, string(route.Raw()), string(posted.Route))
+
+	t.Run("route built manually", func(t *testing.T) {
+		b, err := json.Marshal(jupiter.Route{InAmount: "1", MarketInfos: []jupiter.MarketInfo{{Label: "Orca"}}})
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"inAmount":"1"`)
+		assert.Contains(t, string(b), `"label":"Orca"`)
+	})
+}
+
+func TestResponseMeta(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/price": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"SOL":{"id":"So11111111111111111111111111111111111111112","price":21.1}},"timeTaken":0.001,"contextSlot":190000001}`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	before := time.Now()
+	quotes, err := c.Quote(jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100000})
+	require.NoError(t, err)
+
+	meta := quotes.Meta()
+	assert.Equal(t, int64(190000000), meta.ContextSlot)
+	assert.Equal(t, 0.042, meta.TimeTaken)
+	assert.False(t, meta.FetchedAt.Before(before))
+	assert.Greater(t, meta.Latency, time.Duration(0))
+	for _, route := range quotes {
+		assert.Equal(t, meta, route.Meta())
+	}
+
+	prices, err := c.Price(jupiter.PriceParams{IDs: "SOL"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(190000001), prices.Meta().ContextSlot)
+	assert.Equal(t, int64(190000001), prices["SOL"].Meta().ContextSlot)
+}
+
+func TestGetBestRoute(t *testing.T) {
+	t.Run("exact in", func(t *testing.T) {
+		routes := jupiter.QuoteResponse{
+			{InAmount: "1000", OutAmount: "2105", SwapMode: jupiter.SwapModeExactIn},
+			{InAmount: "1000", OutAmount: "2110", SwapMode: jupiter.SwapModeExactIn},
+			{InAmount: "1000", OutAmount: "2100", SwapMode: jupiter.SwapModeExactIn},
+		}
+
+		route, err := routes.GetBestRoute()
+		require.NoError(t, err)
+		assert.Equal(t, "2110", route.OutAmount)
+	})
+
+	t.Run("exact out", func(t *testing.T) {
+		// The output is fixed, so the best route takes the least input.
+		routes := jupiter.QuoteResponse{
+			{InAmount: "1005", OutAmount: "2000", SwapMode: jupiter.SwapModeExactOut},
+			{InAmount: "1000", OutAmount: "2000", SwapMode: jupiter.SwapModeExactOut},
+			{InAmount: "1010", OutAmount: "2000", SwapMode: jupiter.SwapModeExactOut},
+		}
+
+		route, err := routes.GetBestRoute()
+		require.NoError(t, err)
+		assert.Equal(t, "1000", route.InAmount)
+	})
+
+	t.Run("no routes", func(t *testing.T) {
+		_, err := jupiter.QuoteResponse{}.GetBestRoute()
+		require.ErrorIs(t, err, jupiter.ErrNoRoute)
+	})
+}
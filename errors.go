@@ -1,5 +1,101 @@
 package jupiter
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
-var ErrNoRoute = errors.New("no route found")
+var (
+	ErrNoRoute            = errors.New("no route found")
+	ErrInvalidParams      = errors.New("invalid params")
+	ErrQuoteExpired       = errors.New("quote expired")
+	ErrQuoteDeviation     = errors.New("re-quoted amount deviates beyond tolerance")
+	ErrUnknownMint        = errors.New("unknown mint")
+	ErrPriceImpactTooHigh = errors.New("price impact too high")
+	ErrOutputBelowMinimum = errors.New("output below minimum")
+	ErrInsufficientFunds  = errors.New("insufficient funds")
+	ErrNoRPCEndpoint      = errors.New("rpc endpoint is not configured")
+	ErrStaleSlot          = errors.New("quote context slot is stale")
+	ErrTWAPRunning        = errors.New("twap order is already running")
+)
+
+// ValidationError describes a single invalid request parameter.
+type ValidationError struct {
+	Field   string // name of the invalid field
+	Message string // human-readable description of the problem
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Is reports whether the error matches ErrInvalidParams.
+func (e ValidationError) Is(target error) bool {
+	return target == ErrInvalidParams
+}
+
+// ValidationErrors is a list of parameter validation errors.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether the error matches ErrInvalidParams.
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrInvalidParams
+}
+
+// add appends a validation error for the given field.
+func (e *ValidationErrors) add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
+
+// err returns the validation errors as an error, or nil if there are none.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// InsufficientFundsError describes a wallet balance that doesn't cover the swap.
+type InsufficientFundsError struct {
+	Mint      string // mint of the token, wrapped SOL mint for the native SOL balance
+	Required  uint64 // required amount in base units
+	Available uint64 // wallet balance in base units
+	Shortfall uint64 // missing amount in base units
+}
+
+// Error implements the error interface.
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("insufficient funds: %s balance %d, required %d, shortfall %d", e.Mint, e.Available, e.Required, e.Shortfall)
+}
+
+// Is reports whether the error matches ErrInsufficientFunds.
+func (e *InsufficientFundsError) Is(target error) bool {
+	return target == ErrInsufficientFunds
+}
+
+// StaleSlotError describes a quote computed at a slot older than required.
+type StaleSlotError struct {
+	Slot    int64 // context slot of the quote
+	MinSlot int64 // minimum acceptable slot
+}
+
+// Error implements the error interface.
+func (e *StaleSlotError) Error() string {
+	return fmt.Sprintf("quote context slot is stale: slot %d, minimum %d", e.Slot, e.MinSlot)
+}
+
+// Is reports whether the error matches ErrStaleSlot.
+func (e *StaleSlotError) Is(target error) bool {
+	return target == ErrStaleSlot
+}
@@ -0,0 +1,73 @@
+package jupiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoTransactionSender is returned by the swap executor without the transaction sender.
+var ErrNoTransactionSender = errors.New("transaction sender is not set")
+
+type (
+	// Executor executes swaps, e.g. on chain with SwapExecutor.
+	Executor interface {
+		// Execute executes the best swap for the params and returns the fill.
+		Execute(ctx context.Context, params BestSwapParams) (Fill, error)
+	}
+
+	// Fill is the result of an executed swap.
+	Fill struct {
+		InAmount  uint64    `json:"inAmount"`            // amount of input token sent
+		OutAmount uint64    `json:"outAmount"`           // amount of output token received
+		Signature string    `json:"signature,omitempty"` // transaction signature, empty if no transaction was sent
+		Route     Route     `json:"route"`               // executed route
+		At        time.Time `json:"at"`                  // time of the execution
+	}
+
+	// TransactionSender signs and sends the base64 encoded swap transaction
+	// and returns the transaction signature.
+	TransactionSender func(ctx context.Context, tx string) (string, error)
+
+	// SwapExecutor executes swaps on chain: it builds the transaction with BestSwap
+	// and passes it to the transaction sender.
+	SwapExecutor struct {
+		client *Client
+		send   TransactionSender
+	}
+)
+
+// NewSwapExecutor returns a new swap executor sending the transactions built by the client with send.
+func NewSwapExecutor(client *Client, send TransactionSender) *SwapExecutor {
+	return &SwapExecutor{
+		client: client,
+		send:   send,
+	}
+}
+
+// Execute builds the best swap transaction for the params and sends it.
+// The fill amounts are the expected amounts of the executed route.
+func (e *SwapExecutor) Execute(ctx context.Context, params BestSwapParams) (Fill, error) {
+	if e.send == nil {
+		return Fill{}, ErrNoTransactionSender
+	}
+
+	plan, tx, err := e.client.bestSwap(ctx, params)
+	if err != nil {
+		return Fill{}, err
+	}
+
+	signature, err := e.send(ctx, tx)
+	if err != nil {
+		return Fill{}, fmt.Errorf("failed to send swap transaction: %w", err)
+	}
+
+	return Fill{
+		InAmount:  plan.InAmount,
+		OutAmount: plan.OutAmount,
+		Signature: signature,
+		Route:     plan.Route,
+		At:        e.client.now(),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package jupiter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapExecutor(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	var sent string
+	executor := jupiter.NewSwapExecutor(c, func(ctx context.Context, tx string) (string, error) {
+		sent = tx
+		return "5sig", nil
+	})
+
+	fill, err := executor.Execute(context.Background(), jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dHg=", sent)
+	assert.Equal(t, "5sig", fill.Signature)
+	assert.Equal(t, uint64(100000), fill.InAmount)
+	assert.Equal(t, uint64(2110), fill.OutAmount)
+	assert.Equal(t, "Orca (Whirlpools)", fill.Route.MarketInfos[0].Label)
+
+	t.Run("no sender", func(t *testing.T) {
+		_, err := jupiter.NewSwapExecutor(c, nil).Execute(context.Background(), jupiter.BestSwapParams{})
+		require.ErrorIs(t, err, jupiter.ErrNoTransactionSender)
+	})
+}
+
+func TestExecutorCancel(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		// The quote hangs until the request is canceled.
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	}
+
+	for name, executor := range map[string]jupiter.Executor{
+		"swap": jupiter.NewSwapExecutor(c, func(ctx context.Context, tx string) (string, error) {
+			return "", nil
+		}),
+		"paper": jupiter.NewPaperExecutor(c, jupiter.NewLedger(nil)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err := executor.Execute(ctx, params)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package jupiter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dmitrymomot/jupiter/utils"
+)
+
+type (
+	// RouteExplanation is a human-readable breakdown of a route.
+	RouteExplanation struct {
+		SwapMode       string           `json:"swapMode"`
+		Input          TokenAmount      `json:"input"`
+		Output         TokenAmount      `json:"output"`
+		PriceImpactPct float64          `json:"priceImpactPct"`
+		Hops           []HopExplanation `json:"hops"`
+	}
+
+	// HopExplanation is a single hop of the route through a DEX market.
+	HopExplanation struct {
+		Label              string       `json:"label"`  // DEX name
+		Market             string       `json:"market"` // market ID
+		Input              TokenAmount  `json:"input"`
+		Output             TokenAmount  `json:"output"`
+		LpFee              *TokenAmount `json:"lpFee,omitempty"`
+		LpFeePct           float64      `json:"lpFeePct"`
+		PlatformFee        *TokenAmount `json:"platformFee,omitempty"`
+		PlatformFeePct     float64      `json:"platformFeePct"`
+		PriceImpactPct     float64      `json:"priceImpactPct"`
+		NotEnoughLiquidity bool         `json:"notEnoughLiquidity"`
+	}
+
+	// TokenAmount is an amount of a token, annotated with the token metadata when it's known.
+	TokenAmount struct {
+		Mint     string `json:"mint"`
+		Symbol   string `json:"symbol,omitempty"`
+		Decimals *uint8 `json:"decimals,omitempty"`
+		Amount   string `json:"amount"`             // amount in base units
+		UIAmount string `json:"uiAmount,omitempty"` // amount in token units, only if decimals are known
+	}
+)
+
+// newTokenAmount returns the token amount annotated with the token metadata from the registry.
+func newTokenAmount(tokens TokenRegistry, mint, amount string) TokenAmount {
+	ta := TokenAmount{Mint: mint, Amount: amount}
+	if tokens == nil {
+		return ta
+	}
+	token, ok := tokens.Token(mint)
+	if !ok {
+		return ta
+	}
+
+	ta.Symbol = token.Symbol
+	ta.Decimals = utils.Pointer(token.Decimals)
+	if v, err := strconv.ParseUint(amount, 10, 64); err == nil {
+		ta.UIAmount = utils.AmountToString(v, token.Decimals)
+	}
+
+	return ta
+}
+
+// String returns the amount with the token symbol if known, otherwise with the shortened mint address.
+func (a TokenAmount) String() string {
+	amount, name := a.Amount, a.Symbol
+	if a.UIAmount != "" {
+		amount = a.UIAmount
+	}
+	if name == "" {
+		name = shortMint(a.Mint)
+	}
+	return amount + " " + name
+}
+
+// shortMint shortens the mint address for display, e.g. EPjF...Dt1v.
+func shortMint(mint string) string {
+	if len(mint) <= 10 {
+		return mint
+	}
+	return mint[:4] + "..." + mint[len(mint)-4:]
+}
+
+// Explain returns a human-readable breakdown of the route: the hop path with DEX labels,
+// in/out amounts, LP and platform fees and price impact per hop.
+// Tokens are annotated with symbols and decimals from the registry, which may be nil.
+func (r Route) Explain(tokens TokenRegistry) RouteExplanation {
+	e := RouteExplanation{
+		SwapMode:       r.SwapMode,
+		PriceImpactPct: r.PriceImpactPct,
+		Hops:           make([]HopExplanation, 0, len(r.MarketInfos)),
+	}
+	if len(r.MarketInfos) > 0 {
+		e.Input = newTokenAmount(tokens, r.MarketInfos[0].InputMint, r.InAmount)
+		e.Output = newTokenAmount(tokens, r.MarketInfos[len(r.MarketInfos)-1].OutputMint, r.OutAmount)
+	}
+
+	for _, mi := range r.MarketInfos {
+		hop := HopExplanation{
+			Label:              mi.Label,
+			Market:             mi.ID,
+			Input:              newTokenAmount(tokens, mi.InputMint, mi.InAmount),
+			Output:             newTokenAmount(tokens, mi.OutputMint, mi.OutAmount),
+			PriceImpactPct:     mi.PriceImpactPct,
+			NotEnoughLiquidity: mi.NotEnoughLiquidity,
+		}
+		if mi.LpFee != nil {
+			hop.LpFee = utils.Pointer(newTokenAmount(tokens, mi.LpFee.Mint, mi.LpFee.Amount))
+			hop.LpFeePct = mi.LpFee.Pct
+		}
+		if mi.PlatformFee != nil {
+			hop.PlatformFee = utils.Pointer(newTokenAmount(tokens, mi.PlatformFee.Mint, mi.PlatformFee.Amount))
+			hop.PlatformFeePct = mi.PlatformFee.Pct
+		}
+		e.Hops = append(e.Hops, hop)
+	}
+
+	return e
+}
+
+// String renders the route explanation as text, one line per hop.
+func (e RouteExplanation) String() string {
+	var b strings.Builder
+
+	hops := "hops"
+	if len(e.Hops) == 1 {
+		hops = "hop"
+	}
+	fmt.Fprintf(&b, "%s: %s -> %s via %d %s, price impact %s",
+		e.SwapMode, e.Input, e.Output, len(e.Hops), hops, formatPct(e.PriceImpactPct))
+
+	for i, hop := range e.Hops {
+		fmt.Fprintf(&b, "\n  %d. %s: %s -> %s", i+1, hop.Label, hop.Input, hop.Output)
+		if hop.LpFee != nil {
+			fmt.Fprintf(&b, ", LP fee %s (%s)", hop.LpFee, formatPct(hop.LpFeePct))
+		}
+		if hop.PlatformFee != nil && hop.PlatformFee.Amount != "0" {
+			fmt.Fprintf(&b, ", platform fee %s (%s)", hop.PlatformFee, formatPct(hop.PlatformFeePct))
+		}
+		fmt.Fprintf(&b, ", price impact %s", formatPct(hop.PriceImpactPct))
+		if hop.NotEnoughLiquidity {
+			b.WriteString(", not enough liquidity")
+		}
+	}
+
+	return b.String()
+}
+
+// formatPct formats the fraction as a percentage, e.g. 0.0003 as 0.03%.
+func formatPct(v float64) string {
+	return utils.Float64ToString(v*100) + "%"
+}
@@ -0,0 +1,59 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadQuote loads the quote response from the test fixture.
+func loadQuote(t testing.TB) jupiter.QuoteResponse {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/quote.json")
+	require.NoError(t, err)
+
+	var resp struct {
+		Data jupiter.QuoteResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+
+	return resp.Data
+}
+
+// testTokens is a token registry for the test fixtures.
+var testTokens = jupiter.NewTokenMap([]jupiter.Token{
+	{Address: "So11111111111111111111111111111111111111112", Symbol: "SOL", Decimals: 9},
+	{Address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Symbol: "USDC", Decimals: 6},
+})
+
+func TestRouteExplain(t *testing.T) {
+	route := loadQuote(t)[0]
+
+	t.Run("with tokens", func(t *testing.T) {
+		e := route.Explain(testTokens)
+		require.Len(t, e.Hops, 1)
+		assert.Equal(t, "SOL", e.Input.Symbol)
+		assert.Equal(t, "0.0001", e.Input.UIAmount)
+		assert.Equal(t, "0.00211", e.Output.UIAmount)
+		assert.Equal(t, "Orca (Whirlpools)", e.Hops[0].Label)
+		assert.Equal(t, "0.00000003", e.Hops[0].LpFee.UIAmount)
+
+		assert.Equal(t, `ExactIn: 0.0001 SOL -> 0.00211 USDC via 1 hop, price impact 0.01%
+  1. Orca (Whirlpools): 0.0001 SOL -> 0.00211 USDC, LP fee 0.00000003 SOL (0.03%), price impact 0.01%`, e.String())
+
+		b, err := json.Marshal(e)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"input":{"mint":"So11111111111111111111111111111111111111112","symbol":"SOL","decimals":9,"amount":"100000","uiAmount":"0.0001"}`)
+	})
+
+	t.Run("without tokens", func(t *testing.T) {
+		e := route.Explain(nil)
+		assert.Equal(t, `ExactIn: 100000 So11...1112 -> 2110 EPjF...Dt1v via 1 hop, price impact 0.01%
+  1. Orca (Whirlpools): 100000 So11...1112 -> 2110 EPjF...Dt1v, LP fee 30 So11...1112 (0.03%), price impact 0.01%`, e.String())
+	})
+}
@@ -0,0 +1,90 @@
+package jupiter
+
+import "strings"
+
+// RouteFilter reports whether the route should be kept.
+type RouteFilter func(Route) bool
+
+// Filter returns the routes matching all the given filters, keeping the original order.
+func (q QuoteResponse) Filter(filters ...RouteFilter) QuoteResponse {
+	result := make(QuoteResponse, 0, len(q))
+	for _, route := range q {
+		if matchFilters(route, filters) {
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+// matchFilters returns true if the route matches all the filters.
+func matchFilters(route Route, filters []RouteFilter) bool {
+	for _, f := range filters {
+		if !f(route) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowLabels keeps only the routes going through the markets with the given DEX labels.
+// Labels are compared case-insensitively.
+func AllowLabels(labels ...string) RouteFilter {
+	return func(r Route) bool {
+		for _, mi := range r.MarketInfos {
+			if !containsFold(labels, mi.Label) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DenyLabels drops the routes going through any market with the given DEX labels.
+// Labels are compared case-insensitively.
+func DenyLabels(labels ...string) RouteFilter {
+	return func(r Route) bool {
+		for _, mi := range r.MarketInfos {
+			if containsFold(labels, mi.Label) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MaxHops keeps only the routes with at most n markets.
+func MaxHops(n int) RouteFilter {
+	return func(r Route) bool {
+		return len(r.MarketInfos) <= n
+	}
+}
+
+// SufficientLiquidity drops the routes going through any market flagged with NotEnoughLiquidity.
+func SufficientLiquidity() RouteFilter {
+	return func(r Route) bool {
+		for _, mi := range r.MarketInfos {
+			if mi.NotEnoughLiquidity {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MaxPriceImpact keeps only the routes with the price impact not greater than the given one.
+// The price impact is a fraction, as returned by the API, e.g. 0.01 for 1%.
+func MaxPriceImpact(pct float64) RouteFilter {
+	return func(r Route) bool {
+		return r.PriceImpactPct <= pct
+	}
+}
+
+// containsFold returns true if the list contains the string, compared case-insensitively.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package jupiter_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteResponseFilter(t *testing.T) {
+	quotes := loadQuote(t)
+	require.Len(t, quotes, 2)
+
+	labels := func(q jupiter.QuoteResponse) []string {
+		result := make([]string, 0, len(q))
+		for _, r := range q {
+			result = append(result, r.MarketInfos[0].Label)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Raydium"}, labels(quotes.Filter(jupiter.AllowLabels("raydium"))))
+	assert.Equal(t, []string{"Orca (Whirlpools)"}, labels(quotes.Filter(jupiter.DenyLabels("Raydium"))))
+	assert.Equal(t, []string{"Orca (Whirlpools)", "Raydium"}, labels(quotes.Filter(jupiter.MaxHops(1))))
+	assert.Empty(t, quotes.Filter(jupiter.MaxHops(0)))
+	assert.Equal(t, []string{"Orca (Whirlpools)"}, labels(quotes.Filter(jupiter.MaxPriceImpact(0.00015))))
+	assert.Equal(t, []string{"Orca (Whirlpools)", "Raydium"}, labels(quotes.Filter(jupiter.SufficientLiquidity())))
+	assert.Empty(t, quotes.Filter(jupiter.DenyLabels("Raydium"), jupiter.AllowLabels("Raydium")))
+
+	quotes[1].MarketInfos[0].NotEnoughLiquidity = true
+	assert.Equal(t, []string{"Orca (Whirlpools)"}, labels(quotes.Filter(jupiter.SufficientLiquidity())))
+
+	_, err := quotes.Filter(jupiter.MaxHops(0)).GetBestRoute()
+	require.ErrorIs(t, err, jupiter.ErrNoRoute)
+}
+
+func TestQuoteParamsDexes(t *testing.T) {
+	params := jupiter.QuoteParams{
+		InputMint:    wSolMint,
+		OutputMint:   usdcMint,
+		Amount:       1,
+		ExcludeDexes: []string{"Raydium", "Orca"},
+	}
+	uv, err := utils.StructToUrlValues(params)
+	require.NoError(t, err)
+	assert.Equal(t, "Raydium,Orca", uv.Get("excludeDexes"))
+	assert.False(t, uv.Has("dexes"))
+
+	params.Dexes = []string{"Meteora"}
+	require.ErrorIs(t, params.Validate(), jupiter.ErrInvalidParams)
+}
@@ -0,0 +1,38 @@
+package jupiter
+
+import "fmt"
+
+// checkGuardrails checks the route against the price impact and the minimum output thresholds of the params.
+func checkGuardrails(route Route, params BestSwapParams) error {
+	if params.MaxPriceImpactPct > 0 && route.PriceImpactPct > params.MaxPriceImpactPct {
+		return fmt.Errorf("%w: %s exceeds %s", ErrPriceImpactTooHigh,
+			formatPct(route.PriceImpactPct), formatPct(params.MaxPriceImpactPct))
+	}
+
+	if params.MinOutAmount > 0 {
+		minOut, err := route.MinimumOutAmount()
+		if err != nil {
+			return err
+		}
+		if minOut < params.MinOutAmount {
+			return fmt.Errorf("%w: %d is less than %d", ErrOutputBelowMinimum, minOut, params.MinOutAmount)
+		}
+	}
+
+	return nil
+}
+
+// MinimumOutAmount returns the minimum amount of output token received after slippage:
+// the other amount threshold for ExactIn routes, and the exact output amount for ExactOut routes.
+func (r Route) MinimumOutAmount() (uint64, error) {
+	amount := r.OutAmount
+	if r.SwapMode != SwapModeExactOut && r.OtherAmountThreshold != "" {
+		amount = r.OtherAmountThreshold
+	}
+
+	v, err := parseUint(amount)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse out amount: %w", err)
+	}
+	return v, nil
+}
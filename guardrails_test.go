@@ -0,0 +1,109 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBestSwapGuardrails(t *testing.T) {
+	var slippage string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			slippage = r.URL.Query().Get("slippageBps")
+			serveFixture("testdata/quote.json")(w, r)
+		},
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+		SlippageBps:   50,
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		params := params
+		params.MaxPriceImpactPct = 0.001
+		params.MinOutAmount = 2099
+
+		tx, err := c.BestSwap(params)
+		require.NoError(t, err)
+		assert.Equal(t, "dHg=", tx)
+		assert.Equal(t, "50", slippage)
+	})
+
+	t.Run("price impact too high", func(t *testing.T) {
+		params := params
+		params.MaxPriceImpactPct = 0.00005
+
+		_, err := c.BestSwap(params)
+		require.ErrorIs(t, err, jupiter.ErrPriceImpactTooHigh)
+	})
+
+	t.Run("output below minimum", func(t *testing.T) {
+		params := params
+		params.MinOutAmount = 2100
+
+		_, err := c.BestSwap(params)
+		require.ErrorIs(t, err, jupiter.ErrOutputBelowMinimum)
+	})
+}
+
+func TestBestSwapGuardrailsRequote(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/quote.json")
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)}
+	var quotes int
+	var swapped bool
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			quotes++
+			body := fixture
+			if quotes > 1 {
+				// Same out amounts, but a lower minimum output after slippage.
+				body = bytes.ReplaceAll(body, []byte(`"2099"`), []byte(`"2090"`))
+			}
+			_, _ = w.Write(body)
+		},
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			swapped = true
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+		"/rpc": func(w http.ResponseWriter, r *http.Request) {
+			clock.now = clock.now.Add(10 * time.Second) // slow preflight
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":1},"value":1000000000}}`)
+		},
+	})
+	c := jupiter.NewClient(
+		jupiter.WithAPIURL(srv.URL),
+		jupiter.WithRPCEndpoint(srv.URL+"/rpc"),
+		jupiter.WithClock(clock),
+		jupiter.WithQuoteMaxAge(5*time.Second),
+		jupiter.WithRequote(100),
+	)
+
+	_, err = c.BestSwap(jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+		MinOutAmount:  2099,
+	})
+	require.ErrorIs(t, err, jupiter.ErrOutputBelowMinimum)
+	assert.Equal(t, 2, quotes)
+	assert.False(t, swapped)
+}
@@ -0,0 +1,236 @@
+package jupiter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Execution modes of the executor, see NewExecutor.
+const (
+	ExecutionModeLive  = "live"  // swaps are sent on chain
+	ExecutionModePaper = "paper" // swaps are filled from live quotes against a simulated ledger
+)
+
+type (
+	// Ledger is a simulated wallet: token balances in base units and the history of fills.
+	// Native SOL and wrapped SOL share the wrapped SOL mint balance.
+	// It's safe for concurrent use.
+	Ledger struct {
+		mu       sync.Mutex
+		path     string
+		balances map[string]uint64
+		fills    []Fill
+	}
+
+	// ledgerFile is the JSON file format of the ledger.
+	ledgerFile struct {
+		Balances map[string]uint64 `json:"balances"`
+		Fills    []Fill            `json:"fills"`
+	}
+
+	// PaperExecutor fills swaps from live quotes against a simulated ledger without sending transactions.
+	PaperExecutor struct {
+		client *Client
+		ledger *Ledger
+	}
+
+	// ExecutorConfig configures the executor created with NewExecutor.
+	ExecutorConfig struct {
+		Mode       string            // execution mode, default: live (Available: live, paper)
+		Sender     TransactionSender // transaction sender, required for the live mode
+		LedgerFile string            // file the paper ledger is loaded from and saved to (optional)
+		Balances   map[string]uint64 // initial balances of the paper ledger, if the ledger file doesn't exist yet (optional)
+	}
+)
+
+// NewLedger returns a new in-memory ledger with the given initial balances.
+func NewLedger(balances map[string]uint64) *Ledger {
+	l := &Ledger{balances: make(map[string]uint64, len(balances))}
+	for mint, amount := range balances {
+		l.balances[mint] = amount
+	}
+	return l
+}
+
+// LoadLedger returns the ledger backed by the JSON file at the given path, saved after every change.
+// If the file doesn't exist, the ledger starts with the given initial balances.
+func LoadLedger(path string, balances map[string]uint64) (*Ledger, error) {
+	l := NewLedger(balances)
+	l.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger file: %w", err)
+	}
+
+	var file ledgerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse ledger file: %w", err)
+	}
+	l.balances = file.Balances
+	if l.balances == nil {
+		l.balances = make(map[string]uint64)
+	}
+	l.fills = file.Fills
+
+	return l, nil
+}
+
+// Balance returns the balance of the mint in base units.
+func (l *Ledger) Balance(mint Mint) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.balances[mint.String()]
+}
+
+// Balances returns a copy of all balances by mint.
+func (l *Ledger) Balances() map[string]uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.copyBalances()
+}
+
+// Fills returns a copy of the fill history in order.
+func (l *Ledger) Fills() []Fill {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Fill(nil), l.fills...)
+}
+
+// Deposit adds the amount to the balance of the mint.
+// For a file-backed ledger, the balance is changed only if the file is saved.
+func (l *Ledger) Deposit(mint Mint, amount uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	balances := l.copyBalances()
+	balances[mint.String()] += amount
+
+	return l.commit(balances, l.fills)
+}
+
+// apply debits the fill input and the SOL fees and credits the fill output, if the balances cover it.
+// For a file-backed ledger, the fill is applied only if the file is saved.
+func (l *Ledger) apply(input, output Mint, fill Fill, feeLamports uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Debits are checked together, since the input may be SOL too.
+	debits := map[Mint]uint64{input: fill.InAmount}
+	debits[WrappedSOLMint] += feeLamports
+	for _, mint := range []Mint{input, WrappedSOLMint} {
+		if err := checkBalance(mint, debits[mint], l.balances[mint.String()]); err != nil {
+			return err
+		}
+	}
+
+	balances := l.copyBalances()
+	balances[input.String()] -= fill.InAmount
+	balances[WrappedSOLMint.String()] -= feeLamports
+	balances[output.String()] += fill.OutAmount
+
+	fills := make([]Fill, len(l.fills), len(l.fills)+1)
+	copy(fills, l.fills)
+	fills = append(fills, fill)
+
+	return l.commit(balances, fills)
+}
+
+// commit saves the new balances and fills to the ledger file, if it's file-backed,
+// and replaces the in-memory state once the file is saved. The caller must hold the lock.
+func (l *Ledger) commit(balances map[string]uint64, fills []Fill) error {
+	if l.path != "" {
+		err := writeFileAtomic(l.path, func(w io.Writer) error {
+			if err := json.NewEncoder(w).Encode(ledgerFile{Balances: balances, Fills: fills}); err != nil {
+				return fmt.Errorf("failed to encode ledger: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save ledger: %w", err)
+		}
+	}
+
+	l.balances, l.fills = balances, fills
+	return nil
+}
+
+// copyBalances returns a copy of the balances. The caller must hold the lock.
+func (l *Ledger) copyBalances() map[string]uint64 {
+	balances := make(map[string]uint64, len(l.balances)+1)
+	for mint, amount := range l.balances {
+		balances[mint] = amount
+	}
+	return balances
+}
+
+// NewPaperExecutor returns a new paper-trading executor filling swaps quoted by the client against the ledger.
+func NewPaperExecutor(client *Client, ledger *Ledger) *PaperExecutor {
+	return &PaperExecutor{
+		client: client,
+		ledger: ledger,
+	}
+}
+
+// Ledger returns the ledger of the executor.
+func (e *PaperExecutor) Ledger() *Ledger {
+	return e.ledger
+}
+
+// Execute fills the best swap for the params at the quoted amounts, applying the same guardrails as BestSwap.
+// Route fees and deposits (Fees.TotalFeeAndDeposits) are charged in SOL.
+// If the ledger balances don't cover the swap, *InsufficientFundsError is returned.
+func (e *PaperExecutor) Execute(ctx context.Context, params BestSwapParams) (Fill, error) {
+	if err := params.Validate(); err != nil {
+		return Fill{}, fmt.Errorf("invalid best swap params: %w", err)
+	}
+	params = params.withDefaults()
+
+	plan, err := e.client.planSwap(ctx, params, true)
+	if err != nil {
+		return Fill{}, err
+	}
+
+	fill := Fill{
+		InAmount:  plan.InAmount,
+		OutAmount: plan.OutAmount,
+		Route:     plan.Route,
+		At:        e.client.now(),
+	}
+	if err := e.ledger.apply(params.InputMint, params.OutputMint, fill, plan.Fees.TotalFeeAndDeposits); err != nil {
+		return Fill{}, err
+	}
+
+	return fill, nil
+}
+
+// NewExecutor returns the executor for the configured execution mode,
+// so strategies can switch between live and paper trading with configuration.
+func NewExecutor(client *Client, cfg ExecutorConfig) (Executor, error) {
+	switch cfg.Mode {
+	case "", ExecutionModeLive:
+		if cfg.Sender == nil {
+			return nil, ErrNoTransactionSender
+		}
+		return NewSwapExecutor(client, cfg.Sender), nil
+	case ExecutionModePaper:
+		if cfg.LedgerFile == "" {
+			return NewPaperExecutor(client, NewLedger(cfg.Balances)), nil
+		}
+		ledger, err := LoadLedger(cfg.LedgerFile, cfg.Balances)
+		if err != nil {
+			return nil, err
+		}
+		return NewPaperExecutor(client, ledger), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown execution mode %q", ErrInvalidParams, cfg.Mode)
+	}
+}
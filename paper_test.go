@@ -0,0 +1,103 @@
+package jupiter_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaperExecutor(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			t.Error("swap endpoint must not be called")
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+	path := filepath.Join(t.TempDir(), "ledger.json")
+
+	executor, err := jupiter.NewExecutor(c, jupiter.ExecutorConfig{
+		Mode:       jupiter.ExecutionModePaper,
+		LedgerFile: path,
+		Balances:   map[string]uint64{wSolMint.String(): 3000000},
+	})
+	require.NoError(t, err)
+
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	}
+
+	fill, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100000), fill.InAmount)
+	assert.Equal(t, uint64(2110), fill.OutAmount)
+	assert.Empty(t, fill.Signature)
+
+	// The input and the route fees and deposits are charged in SOL.
+	want := map[string]uint64{
+		wSolMint.String(): 3000000 - 100000 - 2044280,
+		usdcMint.String(): 2110,
+	}
+	ledger := executor.(*jupiter.PaperExecutor).Ledger()
+	assert.Equal(t, want, ledger.Balances())
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		_, err := executor.Execute(context.Background(), params)
+		var fundsErr *jupiter.InsufficientFundsError
+		require.ErrorAs(t, err, &fundsErr)
+		assert.Equal(t, wSolMint.String(), fundsErr.Mint)
+		assert.Equal(t, uint64(2144280-855720), fundsErr.Shortfall)
+		assert.Equal(t, want, ledger.Balances())
+	})
+
+	t.Run("file backed", func(t *testing.T) {
+		loaded, err := jupiter.LoadLedger(path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, loaded.Balances())
+		require.Len(t, loaded.Fills(), 1)
+		assert.Equal(t, uint64(2110), loaded.Fills()[0].OutAmount)
+
+		require.NoError(t, loaded.Deposit(wSolMint, 1000000))
+		reloaded, err := jupiter.LoadLedger(path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1855720), reloaded.Balance(wSolMint))
+	})
+
+	t.Run("save failure", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "ledger.json")
+		ledger, err := jupiter.LoadLedger(path, map[string]uint64{wSolMint.String(): 3000000})
+		require.NoError(t, err)
+		executor := jupiter.NewPaperExecutor(c, ledger)
+
+		_, err = executor.Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Equal(t, map[string]uint64{wSolMint.String(): 3000000}, ledger.Balances())
+		assert.Empty(t, ledger.Fills())
+
+		require.Error(t, ledger.Deposit(usdcMint, 100))
+		assert.Zero(t, ledger.Balance(usdcMint))
+	})
+
+	t.Run("modes", func(t *testing.T) {
+		_, err := jupiter.NewExecutor(c, jupiter.ExecutorConfig{})
+		require.ErrorIs(t, err, jupiter.ErrNoTransactionSender)
+
+		_, err = jupiter.NewExecutor(c, jupiter.ExecutorConfig{Mode: "backtest"})
+		require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+
+		executor, err := jupiter.NewExecutor(c, jupiter.ExecutorConfig{
+			Mode:   jupiter.ExecutionModeLive,
+			Sender: func(ctx context.Context, tx string) (string, error) { return "", nil },
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &jupiter.SwapExecutor{}, executor)
+	})
+}
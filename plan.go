@@ -0,0 +1,138 @@
+package jupiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type (
+	// SwapPlan is what BestSwap would do for the given params, without building the transaction.
+	SwapPlan struct {
+		Route          Route    `json:"route"`          // selected route
+		Alternatives   []Route  `json:"alternatives"`   // other routes considered
+		SwapMode       string   `json:"swapMode"`       // swap mode of the selected route
+		InAmount       uint64   `json:"inAmount"`       // expected amount of input token
+		OutAmount      uint64   `json:"outAmount"`      // expected amount of output token
+		MinimumOut     uint64   `json:"minimumOut"`     // minimum amount of output token received after slippage
+		MaximumIn      uint64   `json:"maximumIn"`      // maximum amount of input token sent after slippage
+		PriceImpactPct float64  `json:"priceImpactPct"` // price impact as a fraction
+		Fees           PlanFees `json:"fees"`           // fee breakdown
+		RequiredSOL    uint64   `json:"requiredSOL"`    // lamports the user wallet needs to hold for the swap
+	}
+
+	// PlanFees is the fee breakdown of the planned swap.
+	PlanFees struct {
+		CostBreakdown              // fees per mint, network fee and deposits, see Route.CostBreakdown
+		TotalFeeAndDeposits uint64 `json:"totalFeeAndDeposits"` // lamports, as reported by the API
+	}
+)
+
+// PlanSwap returns the plan of the swap BestSwap would make for the given params without calling the swap endpoint:
+// the selected route, the alternatives considered, expected amounts, fees and the SOL balance required.
+// The quote is requested with the user public key, so route fees and deposits are returned by the API.
+// If the selected route trips a guardrail, the plan is returned together with
+// ErrPriceImpactTooHigh or ErrOutputBelowMinimum.
+func (c *Client) PlanSwap(params BestSwapParams) (SwapPlan, error) {
+	if err := params.Validate(); err != nil {
+		return SwapPlan{}, fmt.Errorf("invalid best swap params: %w", err)
+	}
+	return c.planSwap(context.Background(), params.withDefaults(), true)
+}
+
+// planSwap plans the swap for the validated params with defaults applied.
+// If withFees is true, the quote is requested with the user public key to get route fees and deposits.
+// The requests are bound to ctx.
+func (c *Client) planSwap(ctx context.Context, params BestSwapParams, withFees bool) (SwapPlan, error) {
+	quoteParams := params.quoteParams()
+	if withFees || params.NetOfFees {
+		quoteParams.UserPublicKey = params.UserPublicKey
+	}
+
+	routes, err := c.quote(ctx, quoteParams)
+	if err != nil {
+		return SwapPlan{}, err
+	}
+
+	lamportValue, err := c.rankingLamportValue(ctx, routes, params)
+	if err != nil {
+		return SwapPlan{}, err
+	}
+
+	best, err := routes.bestRouteIndex(lamportValue)
+	if err != nil {
+		return SwapPlan{}, err
+	}
+
+	plan, err := newSwapPlan(routes, best, params)
+	if err != nil {
+		return SwapPlan{}, err
+	}
+
+	return plan, checkGuardrails(plan.Route, params)
+}
+
+// newSwapPlan builds the swap plan for the route with the given index.
+func newSwapPlan(routes QuoteResponse, best int, params BestSwapParams) (SwapPlan, error) {
+	route := routes[best]
+	plan := SwapPlan{
+		Route:          route,
+		Alternatives:   make([]Route, 0, len(routes)-1),
+		SwapMode:       route.SwapMode,
+		PriceImpactPct: route.PriceImpactPct,
+	}
+	plan.Alternatives = append(plan.Alternatives, routes[:best]...)
+	plan.Alternatives = append(plan.Alternatives, routes[best+1:]...)
+
+	var err error
+	if plan.InAmount, err = parseUint(route.InAmount); err != nil {
+		return SwapPlan{}, fmt.Errorf("failed to parse in amount: %w", err)
+	}
+	if plan.OutAmount, err = parseUint(route.OutAmount); err != nil {
+		return SwapPlan{}, fmt.Errorf("failed to parse out amount: %w", err)
+	}
+	if plan.MinimumOut, err = route.MinimumOutAmount(); err != nil {
+		return SwapPlan{}, err
+	}
+	plan.MaximumIn = plan.InAmount
+	if route.SwapMode == SwapModeExactOut && route.OtherAmountThreshold != "" {
+		if plan.MaximumIn, err = parseUint(route.OtherAmountThreshold); err != nil {
+			return SwapPlan{}, fmt.Errorf("failed to parse other amount threshold: %w", err)
+		}
+	}
+
+	if plan.Fees.CostBreakdown, err = route.CostBreakdown(); err != nil {
+		return SwapPlan{}, err
+	}
+	if f := route.Fees; f != nil {
+		plan.Fees.TotalFeeAndDeposits = uint64(f.TotalFeeAndDeposits)
+		plan.RequiredSOL = uint64(f.MinimumSolForTransaction)
+	}
+
+	// Wrapped SOL is created from the native SOL balance.
+	if params.InputMint == WrappedSOLMint && (params.WrapUnwrapSol == nil || *params.WrapUnwrapSol) {
+		if required := plan.Fees.TotalFeeAndDeposits + plan.MaximumIn; required > plan.RequiredSOL {
+			plan.RequiredSOL = required
+		}
+	}
+
+	return plan, nil
+}
+
+// sumLamports returns the sum of the lamport values.
+func sumLamports(values []int64) uint64 {
+	var sum uint64
+	for _, v := range values {
+		sum += uint64(v)
+	}
+	return sum
+}
+
+// parseUint parses the decimal amount string.
+func parseUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty amount")
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
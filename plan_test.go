@@ -0,0 +1,64 @@
+package jupiter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSwap(t *testing.T) {
+	var user string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			user = r.URL.Query().Get("userPublicKey")
+			serveFixture("testdata/quote.json")(w, r)
+		},
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			t.Error("swap endpoint must not be called")
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	}
+
+	plan, err := c.PlanSwap(params)
+	require.NoError(t, err)
+	assert.Equal(t, userPublicKey.String(), user)
+
+	assert.Equal(t, "Orca (Whirlpools)", plan.Route.MarketInfos[0].Label)
+	require.Len(t, plan.Alternatives, 1)
+	assert.Equal(t, "Raydium", plan.Alternatives[0].MarketInfos[0].Label)
+	assert.Equal(t, jupiter.SwapModeExactIn, plan.SwapMode)
+	assert.Equal(t, uint64(100000), plan.InAmount)
+	assert.Equal(t, uint64(2110), plan.OutAmount)
+	assert.Equal(t, uint64(2099), plan.MinimumOut)
+	assert.Equal(t, uint64(100000), plan.MaximumIn)
+	assert.Equal(t, jupiter.PlanFees{
+		CostBreakdown: jupiter.CostBreakdown{
+			LpFees:       map[string]uint64{wSolMint.String(): 30},
+			PlatformFees: map[string]uint64{},
+			SignatureFee: 5000,
+			AtaDeposits:  2039280,
+		},
+		TotalFeeAndDeposits: 2044280,
+	}, plan.Fees)
+	// The input is wrapped from native SOL, so it's required on top of fees and deposits.
+	assert.Equal(t, uint64(2144280), plan.RequiredSOL)
+
+	t.Run("guardrail", func(t *testing.T) {
+		params := params
+		params.MinOutAmount = 3000
+
+		plan, err := c.PlanSwap(params)
+		require.ErrorIs(t, err, jupiter.ErrOutputBelowMinimum)
+		assert.Equal(t, uint64(2110), plan.OutAmount)
+	})
+}
@@ -0,0 +1,49 @@
+package jupiter
+
+import (
+	"context"
+	"fmt"
+)
+
+// Preflight checks that the user wallet holds enough SOL and input token for the swap plan.
+// It returns *InsufficientFundsError matching ErrInsufficientFunds if a balance falls short.
+// The required SOL is known only for plans with route fees, see PlanSwap.
+// It requires the RPC endpoint, see WithRPCEndpoint.
+func (c *Client) Preflight(params BestSwapParams, plan SwapPlan) error {
+	return c.preflight(context.Background(), params.withDefaults(), plan)
+}
+
+// preflight checks the wallet balances for the swap plan of the params with defaults applied.
+func (c *Client) preflight(ctx context.Context, params BestSwapParams, plan SwapPlan) error {
+	// Wrapped SOL is created from the native SOL balance, so it's included in the required SOL.
+	wrapped := params.InputMint.Equals(WrappedSOLMint) && *params.WrapUnwrapSol
+	if !wrapped {
+		balance, err := c.tokenBalance(ctx, params.UserPublicKey, params.InputMint)
+		if err != nil {
+			return fmt.Errorf("failed to get input token balance: %w", err)
+		}
+		if err := checkBalance(params.InputMint, plan.MaximumIn, balance); err != nil {
+			return err
+		}
+	}
+
+	balance, err := c.solBalance(ctx, params.UserPublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to get SOL balance: %w", err)
+	}
+
+	return checkBalance(WrappedSOLMint, plan.RequiredSOL, balance)
+}
+
+// checkBalance returns *InsufficientFundsError if the balance is below the required amount.
+func checkBalance(mint Mint, required, balance uint64) error {
+	if balance >= required {
+		return nil
+	}
+	return &InsufficientFundsError{
+		Mint:      mint.String(),
+		Required:  required,
+		Available: balance,
+		Shortfall: required - balance,
+	}
+}
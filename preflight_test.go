@@ -0,0 +1,115 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBestSwapPreflight(t *testing.T) {
+	var solBalance, tokenBalance uint64
+	var swapped bool
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			swapped = true
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+		"/rpc": func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Method string        `json:"method"`
+				Params []interface{} `json:"params"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Params) == 0 {
+				t.Errorf("invalid rpc request: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, userPublicKey.String(), req.Params[0])
+
+			switch req.Method {
+			case "getBalance":
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":1},"value":%d}}`, solBalance)
+			case "getTokenAccountsByOwner":
+				// The balance is split between two token accounts.
+				amount := func(v uint64) string {
+					return fmt.Sprintf(`{"account":{"data":{"parsed":{"info":{"tokenAmount":{"amount":"%d"}}}}}}`, v)
+				}
+				fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":1},"value":[%s,%s]}}`,
+					amount(tokenBalance/2), amount(tokenBalance-tokenBalance/2))
+			default:
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`))
+			}
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithRPCEndpoint(srv.URL+"/rpc"))
+
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+	}
+
+	t.Run("insufficient SOL", func(t *testing.T) {
+		solBalance, swapped = 2000000, false
+
+		_, err := c.BestSwap(params)
+		require.ErrorIs(t, err, jupiter.ErrInsufficientFunds)
+		var fundsErr *jupiter.InsufficientFundsError
+		require.ErrorAs(t, err, &fundsErr)
+		assert.Equal(t, jupiter.InsufficientFundsError{
+			Mint:      wSolMint.String(),
+			Required:  2144280,
+			Available: 2000000,
+			Shortfall: 144280,
+		}, *fundsErr)
+		assert.False(t, swapped)
+	})
+
+	t.Run("sufficient SOL", func(t *testing.T) {
+		solBalance, swapped = 3000000, false
+
+		tx, err := c.BestSwap(params)
+		require.NoError(t, err)
+		assert.Equal(t, "dHg=", tx)
+		assert.True(t, swapped)
+	})
+
+	t.Run("insufficient input token", func(t *testing.T) {
+		solBalance, tokenBalance, swapped = 3000000, 50001, false
+		params := params
+		params.WrapUnwrapSol = utils.Pointer(false)
+
+		_, err := c.BestSwap(params)
+		var fundsErr *jupiter.InsufficientFundsError
+		require.ErrorAs(t, err, &fundsErr)
+		assert.Equal(t, jupiter.InsufficientFundsError{
+			Mint:      wSolMint.String(),
+			Required:  100000,
+			Available: 50001,
+			Shortfall: 49999,
+		}, *fundsErr)
+		assert.False(t, swapped)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithRPCEndpoint(srv.URL+"/missing"))
+
+		_, err := c.BestSwap(params)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, jupiter.ErrInsufficientFunds))
+	})
+
+	t.Run("no rpc endpoint", func(t *testing.T) {
+		_, err := jupiter.NewClient(jupiter.WithAPIURL(srv.URL)).SOLBalance(userPublicKey)
+		require.ErrorIs(t, err, jupiter.ErrNoRPCEndpoint)
+	})
+}
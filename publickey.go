@@ -0,0 +1,103 @@
+package jupiter
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/dmitrymomot/jupiter/utils"
+)
+
+// PublicKeyLength is the length of a Solana public key in bytes.
+const PublicKeyLength = 32
+
+type (
+	// PublicKey is a 32-byte Solana public key.
+	// It's marshalled as a base58 encoded string in both JSON and URL query parameters.
+	PublicKey [PublicKeyLength]byte
+
+	// Mint is a token mint address.
+	Mint = PublicKey
+)
+
+// PublicKeyFromBase58 parses a base58 encoded public key.
+func PublicKeyFromBase58(s string) (PublicKey, error) {
+	b, err := utils.Base58Decode(s)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("failed to decode public key %q: %w", s, err)
+	}
+	if len(b) != PublicKeyLength {
+		return PublicKey{}, fmt.Errorf("invalid public key %q: expected %d bytes, got %d", s, PublicKeyLength, len(b))
+	}
+
+	var pk PublicKey
+	copy(pk[:], b)
+
+	return pk, nil
+}
+
+// MustPublicKeyFromBase58 parses a base58 encoded public key and panics if it's invalid.
+// Use it only for compile-time constants, e.g. well-known mint addresses.
+func MustPublicKeyFromBase58(s string) PublicKey {
+	pk, err := PublicKeyFromBase58(s)
+	if err != nil {
+		panic(err)
+	}
+	return pk
+}
+
+// String returns the base58 encoded public key.
+func (pk PublicKey) String() string {
+	return utils.Base58Encode(pk[:])
+}
+
+// IsZero returns true if the public key is not set.
+// Note that the all-zero key is also the System Program address (11111111111111111111111111111111),
+// so it can't be told apart from an unset key: it's omitted from query parameters and rejected
+// by the params validation. None of the params accept the System Program as a valid value.
+func (pk PublicKey) IsZero() bool {
+	return pk == PublicKey{}
+}
+
+// Equals returns true if the public keys are equal.
+func (pk PublicKey) Equals(other PublicKey) bool {
+	return pk == other
+}
+
+// MarshalText implements encoding.TextMarshaler, so the public key is encoded as a base58 string in JSON.
+func (pk PublicKey) MarshalText() ([]byte, error) {
+	return []byte(pk.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pk *PublicKey) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*pk = PublicKey{}
+		return nil
+	}
+
+	parsed, err := PublicKeyFromBase58(string(text))
+	if err != nil {
+		return err
+	}
+	*pk = parsed
+
+	return nil
+}
+
+// EncodeValues implements query.Encoder, so the public key is encoded as a base58 string in URL query parameters.
+// Zero public key is omitted.
+func (pk PublicKey) EncodeValues(key string, v *url.Values) error {
+	if pk.IsZero() {
+		return nil
+	}
+	v.Set(key, pk.String())
+	return nil
+}
+
+// optional returns a pointer to the public key, or nil if it's not set.
+func (pk PublicKey) optional() *PublicKey {
+	if pk.IsZero() {
+		return nil
+	}
+	return &pk
+}
@@ -0,0 +1,61 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey(t *testing.T) {
+	t.Run("parse valid key", func(t *testing.T) {
+		pk, err := jupiter.PublicKeyFromBase58(usdcMint.String())
+		require.NoError(t, err)
+		assert.Equal(t, usdcMint, pk)
+		assert.Equal(t, "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", pk.String())
+		assert.False(t, pk.IsZero())
+	})
+
+	t.Run("system program is zero", func(t *testing.T) {
+		pk, err := jupiter.PublicKeyFromBase58("11111111111111111111111111111111")
+		require.NoError(t, err)
+		assert.True(t, pk.IsZero())
+	})
+
+	t.Run("parse invalid key", func(t *testing.T) {
+		_, err := jupiter.PublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzyba")
+		require.Error(t, err)
+
+		_, err = jupiter.PublicKeyFromBase58("not-a-key")
+		require.ErrorIs(t, err, utils.ErrInvalidBase58)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(jupiter.SwapParams{UserPublicKey: userPublicKey})
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"userPublicKey":"8HwPMNxtFDrvxXn1fJsAYB258TnA6Ydr1DWCtVYgRW4W"`)
+		assert.NotContains(t, string(b), "feeAccount")
+		assert.NotContains(t, string(b), "destinationWallet")
+
+		var rate jupiter.Rate
+		require.NoError(t, json.Unmarshal([]byte(`{"inputMint":"So11111111111111111111111111111111111111112"}`), &rate))
+		assert.Equal(t, wSolMint, rate.InputMint)
+
+		require.Error(t, json.Unmarshal([]byte(`{"inputMint":"invalid"}`), &rate))
+	})
+
+	t.Run("url query", func(t *testing.T) {
+		uv, err := utils.StructToUrlValues(jupiter.QuoteParams{
+			InputMint:  wSolMint,
+			OutputMint: usdcMint,
+			Amount:     100,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, wSolMint.String(), uv.Get("inputMint"))
+		assert.Equal(t, usdcMint.String(), uv.Get("outputMint"))
+		assert.False(t, uv.Has("userPublicKey"))
+	})
+}
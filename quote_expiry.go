@@ -0,0 +1,128 @@
+package jupiter
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Age returns the age of the route relative to the given time.
+// It returns zero if the route was not returned by Client.Quote.
+func (r Route) Age(now time.Time) time.Duration {
+	if r.meta.FetchedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(r.meta.FetchedAt)
+}
+
+// IsStale returns true if the route is older than maxAge relative to the given time.
+func (r Route) IsStale(maxAge time.Duration, now time.Time) bool {
+	return maxAge > 0 && r.Age(now) > maxAge
+}
+
+// freshRoute returns the given route if it's not stale,
+// otherwise re-quotes it if re-quoting is enabled.
+func (c *Client) freshRoute(ctx context.Context, route Route) (Route, error) {
+	if !route.IsStale(c.quoteMaxAge, c.now()) {
+		return route, nil
+	}
+	if !c.requote || route.params == nil {
+		return Route{}, fmt.Errorf("%w: route age %s exceeds %s", ErrQuoteExpired, route.Age(c.now()), c.quoteMaxAge)
+	}
+
+	quotes, err := c.quote(ctx, *route.params)
+	if err != nil {
+		return Route{}, fmt.Errorf("failed to re-quote stale route: %w", err)
+	}
+	requoted, err := quotes.GetBestRoute()
+	if err != nil {
+		return Route{}, fmt.Errorf("failed to re-quote stale route: %w", err)
+	}
+
+	if err := checkDeviation(route, requoted, c.requoteToleranceBps); err != nil {
+		return Route{}, err
+	}
+
+	return requoted, nil
+}
+
+// replan re-quotes the stale route of the plan and returns the plan for the re-quoted route,
+// checked against the guardrails of the params.
+func (c *Client) replan(ctx context.Context, plan SwapPlan, params BestSwapParams) (SwapPlan, error) {
+	route, err := c.freshRoute(ctx, plan.Route)
+	if err != nil {
+		return plan, err
+	}
+
+	fresh, err := newSwapPlan(QuoteResponse{route}, 0, params)
+	if err != nil {
+		return plan, err
+	}
+
+	return fresh, checkGuardrails(fresh.Route, params)
+}
+
+// checkDeviation returns ErrQuoteDeviation if the re-quoted route is worse than the original one
+// by more than toleranceBps basis points.
+func checkDeviation(original, requoted Route, toleranceBps uint64) error {
+	if original.SwapMode == SwapModeExactOut {
+		// The input amount may grow by the tolerance at most.
+		oldIn, newIn, err := parseAmountPair(original.InAmount, requoted.InAmount)
+		if err != nil {
+			return err
+		}
+		limit := new(big.Int).Mul(oldIn, big.NewInt(int64(MaxBps+toleranceBps)))
+		if new(big.Int).Mul(newIn, big.NewInt(MaxBps)).Cmp(limit) > 0 {
+			return fmt.Errorf("%w: in amount %s, originally %s", ErrQuoteDeviation, requoted.InAmount, original.InAmount)
+		}
+		return nil
+	}
+
+	// The output amount may shrink by the tolerance at most.
+	if toleranceBps > MaxBps {
+		toleranceBps = MaxBps
+	}
+	oldOut, newOut, err := parseAmountPair(original.OutAmount, requoted.OutAmount)
+	if err != nil {
+		return err
+	}
+	limit := new(big.Int).Mul(oldOut, big.NewInt(int64(MaxBps-toleranceBps)))
+	if new(big.Int).Mul(newOut, big.NewInt(MaxBps)).Cmp(limit) < 0 {
+		return fmt.Errorf("%w: out amount %s, originally %s", ErrQuoteDeviation, requoted.OutAmount, original.OutAmount)
+	}
+	return nil
+}
+
+// parseAmountPair parses the original and the re-quoted amounts.
+func parseAmountPair(original, requoted string) (*big.Int, *big.Int, error) {
+	a, err := strconv.ParseUint(original, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse amount: %w", err)
+	}
+	b, err := strconv.ParseUint(requoted, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse amount: %w", err)
+	}
+	return new(big.Int).SetUint64(a), new(big.Int).SetUint64(b), nil
+}
+
+// checkSlot returns *StaleSlotError if the quote context slot is older than minSlot,
+// or lags behind the highest seen slot by more than the configured max slot lag (see WithMaxSlotLag).
+func (c *Client) checkSlot(slot, minSlot int64) error {
+	if c.maxSlotLag > 0 {
+		last := c.lastSlot.Load()
+		for slot > last && !c.lastSlot.CompareAndSwap(last, slot) {
+			last = c.lastSlot.Load()
+		}
+		if lagSlot := last - c.maxSlotLag; lagSlot > minSlot {
+			minSlot = lagSlot
+		}
+	}
+
+	if slot < minSlot {
+		return &StaleSlotError{Slot: slot, MinSlot: minSlot}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteExpiry(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/quote.json")
+	require.NoError(t, err)
+
+	var quotesServed int
+	outAmount := "2110"
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			quotesServed++
+			body := bytes.ReplaceAll(fixture, []byte(`"2110"`), []byte(`"`+outAmount+`"`))
+			_, _ = w.Write(bytes.ReplaceAll(body, []byte(`"2105"`), []byte(`"`+outAmount+`"`)))
+		},
+		"/swap": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"swapTransaction":"dHg="}`))
+		},
+	})
+
+	clock := &fakeClock{now: time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)}
+	quoteParams := jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100000}
+
+	getRoute := func(c *jupiter.Client) jupiter.Route {
+		quotes, err := c.Quote(quoteParams)
+		require.NoError(t, err)
+		route, err := quotes.GetBestRoute()
+		require.NoError(t, err)
+		return route
+	}
+
+	t.Run("fresh route", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithClock(clock), jupiter.WithQuoteMaxAge(5*time.Second))
+		route := getRoute(c)
+		assert.Equal(t, clock.now, route.Meta().FetchedAt)
+
+		clock.now = clock.now.Add(5 * time.Second)
+		assert.Equal(t, 5*time.Second, route.Age(clock.now))
+		assert.False(t, route.IsStale(5*time.Second, clock.now))
+
+		_, err := c.Swap(jupiter.SwapParams{Route: route, UserPublicKey: userPublicKey})
+		require.NoError(t, err)
+	})
+
+	t.Run("stale route without requote", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithClock(clock), jupiter.WithQuoteMaxAge(5*time.Second))
+		route := getRoute(c)
+
+		clock.now = clock.now.Add(6 * time.Second)
+		_, err := c.Swap(jupiter.SwapParams{Route: route, UserPublicKey: userPublicKey})
+		require.ErrorIs(t, err, jupiter.ErrQuoteExpired)
+	})
+
+	t.Run("stale route with requote", func(t *testing.T) {
+		c := jupiter.NewClient(
+			jupiter.WithAPIURL(srv.URL),
+			jupiter.WithClock(clock),
+			jupiter.WithQuoteMaxAge(5*time.Second),
+			jupiter.WithRequote(100),
+		)
+		route := getRoute(c)
+		served := quotesServed
+
+		clock.now = clock.now.Add(6 * time.Second)
+		outAmount = "2100" // within 1% tolerance
+		_, err := c.Swap(jupiter.SwapParams{Route: route, UserPublicKey: userPublicKey})
+		require.NoError(t, err)
+		assert.Equal(t, served+1, quotesServed)
+
+		clock.now = clock.now.Add(6 * time.Second)
+		outAmount = "2000" // beyond 1% tolerance
+		_, err = c.Swap(jupiter.SwapParams{Route: route, UserPublicKey: userPublicKey})
+		require.ErrorIs(t, err, jupiter.ErrQuoteDeviation)
+	})
+}
+
+func TestQuoteStaleSlot(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/quote.json")
+	require.NoError(t, err)
+
+	slot := "190000000"
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(bytes.ReplaceAll(fixture, []byte("190000000"), []byte(slot)))
+		},
+	})
+	quoteParams := jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100000}
+
+	t.Run("min context slot", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		params := quoteParams
+		params.MinContextSlot = 190000000
+		_, err := c.Quote(params)
+		require.NoError(t, err)
+
+		params.MinContextSlot = 190000001
+		_, err = c.Quote(params)
+		require.ErrorIs(t, err, jupiter.ErrStaleSlot)
+		var slotErr *jupiter.StaleSlotError
+		require.ErrorAs(t, err, &slotErr)
+		assert.Equal(t, jupiter.StaleSlotError{Slot: 190000000, MinSlot: 190000001}, *slotErr)
+
+		_, err = c.PlanSwap(jupiter.BestSwapParams{
+			UserPublicKey:  userPublicKey,
+			InputMint:      wSolMint,
+			OutputMint:     usdcMint,
+			Amount:         100000,
+			MinContextSlot: 190000001,
+		})
+		require.ErrorIs(t, err, jupiter.ErrStaleSlot)
+	})
+
+	t.Run("max slot lag", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithMaxSlotLag(10))
+
+		slot = "190000100"
+		_, err := c.Quote(quoteParams)
+		require.NoError(t, err)
+
+		slot = "190000090"
+		_, err = c.Quote(quoteParams)
+		require.NoError(t, err)
+
+		slot = "190000089"
+		_, err = c.Quote(quoteParams)
+		require.ErrorIs(t, err, jupiter.ErrStaleSlot)
+	})
+}
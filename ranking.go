@@ -0,0 +1,56 @@
+package jupiter
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// LamportValue returns the value of one lamport in base units of the token with the given mint,
+// using the price endpoint. Token decimals are resolved from the token registry, see WithTokenRegistry.
+func (c *Client) LamportValue(mint Mint) (float64, error) {
+	return c.lamportValue(context.Background(), mint)
+}
+
+// lamportValue returns the value of one lamport in base units of the token, the request is bound to ctx.
+func (c *Client) lamportValue(ctx context.Context, mint Mint) (float64, error) {
+	if mint.Equals(WrappedSOLMint) {
+		return 1, nil
+	}
+
+	decimals, err := c.decimals(mint.String())
+	if err != nil {
+		return 0, err
+	}
+
+	sol := WrappedSOLMint.String()
+	prices, err := c.price(ctx, PriceParams{IDs: sol, VsToken: mint.String()})
+	if err != nil {
+		return 0, err
+	}
+	price, ok := prices[sol]
+	if !ok {
+		return 0, fmt.Errorf("%w: no SOL price in %s", ErrUnknownMint, mint)
+	}
+
+	return price.Price * math.Pow10(int(decimals)) / LamportsPerSOL, nil
+}
+
+// rankingLamportValue returns the lamport value used to rank the routes of the best swap,
+// or zero if the routes are ranked by amounts only.
+func (c *Client) rankingLamportValue(ctx context.Context, routes QuoteResponse, params BestSwapParams) (float64, error) {
+	if !params.NetOfFees || len(routes) < 2 {
+		return 0, nil
+	}
+
+	mint := params.OutputMint
+	if params.SwapMode == SwapModeExactOut {
+		mint = params.InputMint
+	}
+
+	v, err := c.lamportValue(ctx, mint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get lamport value: %w", err)
+	}
+	return v, nil
+}
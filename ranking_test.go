@@ -0,0 +1,84 @@
+package jupiter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBestRouteNetOfFees(t *testing.T) {
+	routes := jupiter.QuoteResponse{
+		{InAmount: "1000", OutAmount: "2110", SwapMode: jupiter.SwapModeExactIn, Fees: &jupiter.RouteFees{TotalFeeAndDeposits: 2044280}},
+		{InAmount: "1000", OutAmount: "2105", SwapMode: jupiter.SwapModeExactIn, Fees: &jupiter.RouteFees{TotalFeeAndDeposits: 5000}},
+	}
+
+	route, err := routes.GetBestRoute()
+	require.NoError(t, err)
+	assert.Equal(t, "2110", route.OutAmount)
+
+	route, err = routes.GetBestRouteNetOfFees(0.02)
+	require.NoError(t, err)
+	assert.Equal(t, "2105", route.OutAmount)
+
+	t.Run("exact out", func(t *testing.T) {
+		routes := jupiter.QuoteResponse{
+			{InAmount: "1010", OutAmount: "2000", SwapMode: jupiter.SwapModeExactOut, Fees: &jupiter.RouteFees{TotalFeeAndDeposits: 5000}},
+			{InAmount: "1000", OutAmount: "2000", SwapMode: jupiter.SwapModeExactOut, Fees: &jupiter.RouteFees{TotalFeeAndDeposits: 2044280}},
+		}
+
+		route, err := routes.GetBestRoute()
+		require.NoError(t, err)
+		assert.Equal(t, "1000", route.InAmount)
+
+		route, err = routes.GetBestRouteNetOfFees(0.01)
+		require.NoError(t, err)
+		assert.Equal(t, "1010", route.InAmount)
+	})
+}
+
+func TestPlanSwapNetOfFees(t *testing.T) {
+	var user, vsToken string
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			user = r.URL.Query().Get("userPublicKey")
+			serveFixture("testdata/quote.json")(w, r)
+		},
+		"/price": func(w http.ResponseWriter, r *http.Request) {
+			vsToken = r.URL.Query().Get("vsToken")
+			w.Header().Set("Content-Type", jupiter.ContentTypeJSON)
+			_, _ = w.Write([]byte(`{"data":{"So11111111111111111111111111111111111111112":{"id":"So11111111111111111111111111111111111111112","price":20}}}`))
+		},
+	})
+	params := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        100000,
+		NetOfFees:     true,
+	}
+
+	t.Run("unknown decimals", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		_, err := c.PlanSwap(params)
+		require.ErrorIs(t, err, jupiter.ErrUnknownMint)
+	})
+
+	c := jupiter.NewClient(
+		jupiter.WithAPIURL(srv.URL),
+		jupiter.WithTokenRegistry(jupiter.NewTokenMap([]jupiter.Token{
+			{Address: usdcMint.String(), Symbol: "USDC", Decimals: 6},
+		})),
+	)
+
+	plan, err := c.PlanSwap(params)
+	require.NoError(t, err)
+	assert.Equal(t, userPublicKey.String(), user)
+	assert.Equal(t, usdcMint.String(), vsToken)
+	// Orca gives more output, but requires a new token account deposit.
+	assert.Equal(t, "Raydium", plan.Route.MarketInfos[0].Label)
+	assert.Equal(t, uint64(2105), plan.OutAmount)
+}
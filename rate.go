@@ -0,0 +1,99 @@
+package jupiter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// optionalDecimals returns the decimals override if set, or the decimals from the token registry,
+// or nil if the decimals are unknown.
+func (c *Client) optionalDecimals(mint Mint, override *uint8) *uint8 {
+	if override != nil {
+		return override
+	}
+	d, err := c.decimals(mint.String())
+	if err != nil {
+		return nil
+	}
+	return &d
+}
+
+// Price returns the exact amount of output token per one input token, adjusted for token decimals.
+// It returns nil if the token decimals are unknown or the input amount is zero.
+func (r Rate) Price() *big.Rat {
+	if r.InputDecimals == nil || r.OutputDecimals == nil || r.InAmount == 0 {
+		return nil
+	}
+	return ratio(r.OutAmount, *r.OutputDecimals, r.InAmount, *r.InputDecimals)
+}
+
+// InversePrice returns the exact amount of input token per one output token, adjusted for token decimals.
+// It returns nil if the token decimals are unknown or the output amount is zero.
+func (r Rate) InversePrice() *big.Rat {
+	if r.InputDecimals == nil || r.OutputDecimals == nil || r.OutAmount == 0 {
+		return nil
+	}
+	return ratio(r.InAmount, *r.InputDecimals, r.OutAmount, *r.OutputDecimals)
+}
+
+// PriceString returns the price as a decimal string with prec digits after the decimal point,
+// or an empty string if the price is unknown.
+func (r Rate) PriceString(prec int) string {
+	return ratString(r.Price(), prec)
+}
+
+// InversePriceString returns the inverse price as a decimal string with prec digits after the decimal point,
+// or an empty string if the inverse price is unknown.
+func (r Rate) InversePriceString(prec int) string {
+	return ratString(r.InversePrice(), prec)
+}
+
+// String returns the rate as one input token priced in output token, e.g. "1 SOL = 20.5 USDC",
+// with the output token precision. If the decimals are unknown, the raw amounts are used instead.
+func (r Rate) String() string {
+	in, out := r.InputSymbol, r.OutputSymbol
+	if in == "" {
+		in = shortMint(r.InputMint.String())
+	}
+	if out == "" {
+		out = shortMint(r.OutputMint.String())
+	}
+
+	price := r.Price()
+	if price == nil {
+		return fmt.Sprintf("%d %s = %d %s", r.InAmount, in, r.OutAmount, out)
+	}
+
+	return fmt.Sprintf("1 %s = %s %s", in, trimZeros(price.FloatString(int(*r.OutputDecimals))), out)
+}
+
+// ratio returns (a / 10^aDecimals) / (b / 10^bDecimals).
+func ratio(a uint64, aDecimals uint8, b uint64, bDecimals uint8) *big.Rat {
+	num := new(big.Int).SetUint64(a)
+	num.Mul(num, pow10(bDecimals))
+	den := new(big.Int).SetUint64(b)
+	den.Mul(den, pow10(aDecimals))
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// pow10 returns 10^n.
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// ratString returns the rational number as a decimal string, or an empty string if it's nil.
+func ratString(r *big.Rat, prec int) string {
+	if r == nil {
+		return ""
+	}
+	return r.FloatString(prec)
+}
+
+// trimZeros removes trailing zeros after the decimal point.
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
@@ -0,0 +1,71 @@
+package jupiter_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatePrice(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": serveFixture("testdata/quote.json"),
+	})
+	params := jupiter.ExchangeRateParams{
+		InputMint:  wSolMint,
+		OutputMint: usdcMint,
+		Amount:     100000,
+	}
+
+	t.Run("registry", func(t *testing.T) {
+		c := jupiter.NewClient(
+			jupiter.WithAPIURL(srv.URL),
+			jupiter.WithTokenRegistry(jupiter.NewTokenMap([]jupiter.Token{
+				{Address: wSolMint.String(), Symbol: "SOL", Decimals: 9},
+				{Address: usdcMint.String(), Symbol: "USDC", Decimals: 6},
+			})),
+		)
+
+		rate, err := c.ExchangeRate(params)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100000), rate.InAmount)
+		assert.Equal(t, uint64(2110), rate.OutAmount)
+		assert.Equal(t, utils.Pointer[uint8](9), rate.InputDecimals)
+		assert.Equal(t, utils.Pointer[uint8](6), rate.OutputDecimals)
+
+		assert.Equal(t, big.NewRat(211, 10), rate.Price())
+		assert.Equal(t, big.NewRat(10, 211), rate.InversePrice())
+		assert.Equal(t, "21.10", rate.PriceString(2))
+		assert.Equal(t, "0.047393", rate.InversePriceString(6))
+		assert.Equal(t, "1 SOL = 21.1 USDC", rate.String())
+	})
+
+	t.Run("override", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		params := params
+		params.OutputDecimals = utils.Pointer[uint8](4)
+
+		rate, err := c.ExchangeRate(params)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(2110, 1), rate.Price())
+		assert.Equal(t, "1 So11...1112 = 2110 EPjF...Dt1v", rate.String())
+	})
+
+	t.Run("unknown decimals", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		rate, err := c.ExchangeRate(params)
+		require.NoError(t, err)
+		// Wrapped SOL decimals are always known.
+		assert.Equal(t, utils.Pointer[uint8](9), rate.InputDecimals)
+		assert.Nil(t, rate.OutputDecimals)
+		assert.Nil(t, rate.Price())
+		assert.Empty(t, rate.InversePriceString(6))
+		assert.Equal(t, "100000 So11...1112 = 2110 EPjF...Dt1v", rate.String())
+	})
+}
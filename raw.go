@@ -0,0 +1,69 @@
+package jupiter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache caches JSON field names per struct type.
+var knownFieldsCache sync.Map // map[reflect.Type]map[string]reflect.StructField
+
+// jsonFields returns the exported JSON fields of the given struct type keyed by their JSON name.
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	if cached, ok := knownFieldsCache.Load(typ); ok {
+		return cached.(map[string]reflect.StructField)
+	}
+
+	fields := make(map[string]reflect.StructField, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = sf.Name
+		}
+		fields[name] = sf
+	}
+
+	knownFieldsCache.Store(typ, fields)
+	return fields
+}
+
+// unknownFields returns the top-level fields of raw JSON object
+// which are not declared in the given struct type.
+func unknownFields(raw json.RawMessage, typ reflect.Type) map[string]json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+
+	known := jsonFields(typ)
+	result := make(map[string]json.RawMessage)
+	for name, val := range obj {
+		if _, ok := known[name]; !ok {
+			result[name] = val
+		}
+	}
+
+	return result
+}
+
+// copyRaw returns a copy of the given raw JSON,
+// since the decoder may reuse the underlying buffer.
+func copyRaw(data []byte) json.RawMessage {
+	raw := make(json.RawMessage, len(data))
+	copy(raw, data)
+	return raw
+}
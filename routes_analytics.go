@@ -0,0 +1,167 @@
+package jupiter
+
+import "sort"
+
+type (
+	// MintDegree is the degree centrality of a mint in the routes graph.
+	MintDegree struct {
+		Mint       string  `json:"mint"`
+		InDegree   int     `json:"inDegree"`   // number of mints which can be swapped into the mint
+		OutDegree  int     `json:"outDegree"`  // number of mints the mint can be swapped into
+		Centrality float64 `json:"centrality"` // (in + out) / (2 * (n - 1)), from 0 to 1
+	}
+
+	// MintReach is the number of mints reachable from a mint via any number of hops.
+	MintReach struct {
+		Mint  string `json:"mint"`
+		Reach int    `json:"reach"`
+	}
+)
+
+// Degree returns the degree centrality of the given mint.
+func (idx *RoutesIndex) Degree(mint string) (MintDegree, bool) {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return MintDegree{}, false
+	}
+	return idx.degree(i), true
+}
+
+// degree returns the degree centrality of the mint with the given index.
+func (idx *RoutesIndex) degree(i uint32) MintDegree {
+	d := MintDegree{
+		Mint:      idx.mints[i],
+		InDegree:  int(idx.inOffsets[i+1] - idx.inOffsets[i]),
+		OutDegree: int(idx.outOffsets[i+1] - idx.outOffsets[i]),
+	}
+	if n := len(idx.mints); n > 1 {
+		d.Centrality = float64(d.InDegree+d.OutDegree) / float64(2*(n-1))
+	}
+	return d
+}
+
+// DegreeCentrality returns the degree centrality of all the mints in the index order.
+func (idx *RoutesIndex) DegreeCentrality() []MintDegree {
+	result := make([]MintDegree, len(idx.mints))
+	for i := range idx.mints {
+		result[i] = idx.degree(uint32(i))
+	}
+	return result
+}
+
+// Hubs returns up to n mints with the highest degree centrality, most connected first.
+func (idx *RoutesIndex) Hubs(n int) []MintDegree {
+	result := idx.DegreeCentrality()
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].InDegree+result[i].OutDegree > result[j].InDegree+result[j].OutDegree
+	})
+	if n >= 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// Components returns the weakly connected components of the routes graph, largest first.
+// Mints of a component are listed in the index order.
+func (idx *RoutesIndex) Components() [][]string {
+	n := len(idx.mints)
+	parent := make([]uint32, n)
+	for i := range parent {
+		parent[i] = uint32(i)
+	}
+	find := func(i uint32) uint32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for i := 0; i < n; i++ {
+		for _, out := range idx.outIndexes(uint32(i)) {
+			a, b := find(uint32(i)), find(out)
+			if a != b {
+				parent[b] = a
+			}
+		}
+	}
+
+	groups := make(map[uint32]int)
+	var components [][]string
+	for i := 0; i < n; i++ {
+		root := find(uint32(i))
+		c, ok := groups[root]
+		if !ok {
+			c = len(components)
+			groups[root] = c
+			components = append(components, nil)
+		}
+		components[c] = append(components[c], idx.mints[i])
+	}
+	sort.SliceStable(components, func(i, j int) bool { return len(components[i]) > len(components[j]) })
+
+	return components
+}
+
+// Reach returns the number of other mints reachable from the given mint via any number of hops.
+// The search stops once more than limit mints are found, so the result is capped at limit+1;
+// a negative limit means no limit.
+func (idx *RoutesIndex) Reach(mint string, limit int) int {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return 0
+	}
+	return newReachSearch(idx).reach(i, limit)
+}
+
+// Islands returns the mints which can reach at most maxReach other mints,
+// i.e. tokens trapped in a small island of the routes graph, least connected first.
+func (idx *RoutesIndex) Islands(maxReach int) []MintReach {
+	search := newReachSearch(idx)
+
+	var result []MintReach
+	for i := range idx.mints {
+		if reach := search.reach(uint32(i), maxReach); reach <= maxReach {
+			result = append(result, MintReach{Mint: idx.mints[i], Reach: reach})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Reach < result[j].Reach })
+
+	return result
+}
+
+// reachSearch is a reusable bounded breadth-first search over the routes graph.
+type reachSearch struct {
+	idx     *RoutesIndex
+	visited []uint32 // generation the mint was visited in
+	gen     uint32
+	queue   []uint32
+}
+
+// newReachSearch returns a new reach search for the given index.
+func newReachSearch(idx *RoutesIndex) *reachSearch {
+	return &reachSearch{idx: idx, visited: make([]uint32, len(idx.mints))}
+}
+
+// reach returns the number of mints reachable from the mint with the given index, capped at limit+1.
+func (s *reachSearch) reach(start uint32, limit int) int {
+	s.gen++
+	s.visited[start] = s.gen
+	s.queue = append(s.queue[:0], start)
+
+	count := 0
+	for head := 0; head < len(s.queue); head++ {
+		for _, out := range s.idx.outIndexes(s.queue[head]) {
+			if s.visited[out] == s.gen {
+				continue
+			}
+			s.visited[out] = s.gen
+			s.queue = append(s.queue, out)
+			count++
+			if limit >= 0 && count > limit {
+				return count
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,60 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesAnalytics(t *testing.T) {
+	// a <-> b <-> c form the main component, d -> e is an island, f is isolated.
+	idx, err := (&jupiter.IndexedRoutesMap{
+		MintKeys: []string{"a", "b", "c", "d", "e", "f"},
+		IndexedRouteMap: map[string][]int{
+			"0": {1, 2},
+			"1": {0, 2},
+			"2": {0, 1},
+			"3": {4},
+		},
+	}).Index()
+	require.NoError(t, err)
+
+	d, ok := idx.Degree("a")
+	require.True(t, ok)
+	assert.Equal(t, jupiter.MintDegree{Mint: "a", InDegree: 2, OutDegree: 2, Centrality: 0.4}, d)
+	assert.Len(t, idx.DegreeCentrality(), 6)
+
+	hubs := idx.Hubs(2)
+	require.Len(t, hubs, 2)
+	assert.Equal(t, "a", hubs[0].Mint)
+	assert.Equal(t, "b", hubs[1].Mint)
+
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e"}, {"f"}}, idx.Components())
+
+	assert.Equal(t, 2, idx.Reach("a", -1))
+	assert.Equal(t, 2, idx.Reach("a", 1), "reach is capped at limit+1")
+	assert.Equal(t, 1, idx.Reach("d", -1))
+	assert.Equal(t, 0, idx.Reach("unknown", -1))
+
+	assert.Equal(t, []jupiter.MintReach{
+		{Mint: "e", Reach: 0},
+		{Mint: "f", Reach: 0},
+		{Mint: "d", Reach: 1},
+	}, idx.Islands(1))
+}
+
+func BenchmarkRoutesAnalytics(b *testing.B) {
+	m, err := jupiter.DecodeCompactRoutesMap(bytes.NewReader(generateRoutesMap(5000, 200)))
+	require.NoError(b, err)
+	idx := m.Index()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Hubs(20)
+		idx.Components()
+		idx.Islands(10)
+	}
+}
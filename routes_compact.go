@@ -0,0 +1,233 @@
+package jupiter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidRoutesMap is returned when the routes map references a mint index out of range.
+var ErrInvalidRoutesMap = errors.New("invalid routes map")
+
+// CompactRoutesMap is a memory-efficient representation of the indexed routes map
+// in the compressed sparse row (CSR) format: indexes of the output mints
+// for the input mint with index i are Edges[Offsets[i]:Offsets[i+1]].
+type CompactRoutesMap struct {
+	MintKeys []string // all the indexed mints
+	Offsets  []uint32 // row offsets into Edges, len(MintKeys)+1 items
+	Edges    []uint32 // output mint indexes of all the rows
+}
+
+// routesChunkSize is the size of the chunks the edges are decoded into.
+// Edges are decoded into fixed-size chunks rather than a single growing slice,
+// so no memory is wasted on re-allocations while the total number of edges is unknown.
+const routesChunkSize = 1 << 16
+
+// routesRow is a single row of the indexed routes map in the order it was decoded.
+type routesRow struct {
+	mint  uint32   // input mint index
+	edges []uint32 // output mint indexes, a sub-slice of a chunk
+}
+
+// DecodeCompactRoutesMap decodes the indexed routes map JSON from the reader into the compact representation.
+// The JSON is decoded in a streaming fashion: mint keys of the indexed route map are never materialized as strings,
+// and output mints of every row are decoded into a reused buffer.
+func DecodeCompactRoutesMap(r io.Reader) (*CompactRoutesMap, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var (
+		m     CompactRoutesMap
+		rows  []routesRow
+		total int
+	)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read routes map field: %w", err)
+		}
+
+		switch key {
+		case "mintKeys":
+			if err := dec.Decode(&m.MintKeys); err != nil {
+				return nil, fmt.Errorf("failed to decode mint keys: %w", err)
+			}
+		case "indexedRouteMap":
+			rows, total, err = decodeIndexedRouteMap(dec)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("failed to skip routes map field %v: %w", key, err)
+			}
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	if err := m.build(rows, total); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// decodeIndexedRouteMap decodes the "indexedRouteMap" object into the list of rows.
+// It returns the rows and the total number of edges.
+func decodeIndexedRouteMap(dec *json.Decoder) ([]routesRow, int, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		rows  []routesRow
+		chunk []uint32
+		buf   []uint32
+		total int
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read indexed route map key: %w", err)
+		}
+		key, _ := tok.(string)
+		mint, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid mint index %v", ErrInvalidRoutesMap, tok)
+		}
+
+		// Decoding into a slice reuses its backing array.
+		buf = buf[:0]
+		if err := dec.Decode(&buf); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode routes for mint index %d: %w", mint, err)
+		}
+
+		if len(buf) > cap(chunk)-len(chunk) {
+			size := routesChunkSize
+			if len(buf) > size {
+				size = len(buf)
+			}
+			chunk = make([]uint32, 0, size)
+		}
+		start := len(chunk)
+		chunk = append(chunk, buf...)
+		rows = append(rows, routesRow{mint: uint32(mint), edges: chunk[start:len(chunk):len(chunk)]})
+		total += len(buf)
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, 0, err
+	}
+
+	return rows, total, nil
+}
+
+// build builds the CSR offsets and edges from the decoded rows.
+func (m *CompactRoutesMap) build(rows []routesRow, total int) error {
+	n := uint32(len(m.MintKeys))
+	m.Offsets = make([]uint32, n+1)
+	for _, row := range rows {
+		if row.mint >= n {
+			return fmt.Errorf("%w: input mint index %d out of range", ErrInvalidRoutesMap, row.mint)
+		}
+		for _, e := range row.edges {
+			if e >= n {
+				return fmt.Errorf("%w: output mint index %d out of range", ErrInvalidRoutesMap, e)
+			}
+		}
+		m.Offsets[row.mint+1] += uint32(len(row.edges))
+	}
+	for i := uint32(0); i < n; i++ {
+		m.Offsets[i+1] += m.Offsets[i]
+	}
+
+	m.Edges = make([]uint32, total)
+	next := make([]uint32, n)
+	copy(next, m.Offsets[:n])
+	for _, row := range rows {
+		next[row.mint] += uint32(copy(m.Edges[next[row.mint]:], row.edges))
+	}
+
+	return nil
+}
+
+// expectDelim reads the next token and checks that it's the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read routes map: %w", err)
+	}
+	if tok != delim {
+		return fmt.Errorf("%w: expected %s, got %v", ErrInvalidRoutesMap, delim, tok)
+	}
+	return nil
+}
+
+// Len returns the number of indexed mints.
+func (m *CompactRoutesMap) Len() int {
+	return len(m.MintKeys)
+}
+
+// RoutesForIndex returns indexes of the output mints for the input mint with the given index.
+// The returned slice shares memory with the routes map and must not be modified.
+func (m *CompactRoutesMap) RoutesForIndex(i int) []uint32 {
+	if i < 0 || i >= len(m.MintKeys) {
+		return nil
+	}
+	return m.Edges[m.Offsets[i]:m.Offsets[i+1]]
+}
+
+// GetRoutesForMint returns the routes for a given mint.
+func (m *CompactRoutesMap) GetRoutesForMint(mint string) []string {
+	for i, key := range m.MintKeys {
+		if key != mint {
+			continue
+		}
+
+		routes := m.RoutesForIndex(i)
+		result := make([]string, 0, len(routes))
+		for _, out := range routes {
+			result = append(result, m.MintKeys[out])
+		}
+		return result
+	}
+
+	return []string{}
+}
+
+// CompactRoutesMap returns the same routes map as RoutesMap, decoded in the memory-efficient compact form.
+// Strict decoding is not applied to the compact routes map.
+func (c *Client) CompactRoutesMap(onlyDirectRoutes bool) (*CompactRoutesMap, error) {
+	return c.compactRoutesMap(context.Background(), onlyDirectRoutes)
+}
+
+// compactRoutesMap fetches the compact routes map within the given context.
+func (c *Client) compactRoutesMap(ctx context.Context, onlyDirectRoutes bool) (*CompactRoutesMap, error) {
+	resp, err := c.get(ctx, c.endpointRoutesMap, url.Values{
+		"onlyDirectRoutes": []string{strconv.FormatBool(onlyDirectRoutes)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to make routes map request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	routesMap, err := DecodeCompactRoutesMap(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routes map response: %w", err)
+	}
+
+	return routesMap, nil
+}
@@ -0,0 +1,113 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCompactRoutesMap(t *testing.T) {
+	data, err := os.ReadFile("testdata/routes_map.json")
+	require.NoError(t, err)
+
+	var indexed jupiter.IndexedRoutesMap
+	require.NoError(t, json.Unmarshal(data, &indexed))
+
+	compact, err := jupiter.DecodeCompactRoutesMap(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 5, compact.Len())
+	assert.Equal(t, []uint32{0, 4, 7, 9, 11, 11}, compact.Offsets)
+	assert.Equal(t, []uint32{1, 2, 3, 4, 0, 2, 3, 0, 1, 0, 1}, compact.Edges)
+	assert.Equal(t, []uint32{0, 1}, compact.RoutesForIndex(2))
+	assert.Empty(t, compact.RoutesForIndex(4))
+	assert.Nil(t, compact.RoutesForIndex(5))
+
+	for _, mint := range append(indexed.MintKeys, "unknown") {
+		assert.Equal(t, indexed.GetRoutesForMint(mint), compact.GetRoutesForMint(mint), mint)
+	}
+
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := jupiter.DecodeCompactRoutesMap(strings.NewReader(`{"mintKeys":["a"],"indexedRouteMap":{"0":[1]}}`))
+		require.ErrorIs(t, err, jupiter.ErrInvalidRoutesMap)
+
+		_, err = jupiter.DecodeCompactRoutesMap(strings.NewReader(`{"mintKeys":["a"],"indexedRouteMap":{"x":[0]}}`))
+		require.ErrorIs(t, err, jupiter.ErrInvalidRoutesMap)
+	})
+
+	t.Run("client", func(t *testing.T) {
+		srv := newTestServer(t, map[string]http.HandlerFunc{
+			"/indexed-route-map": serveFixture("testdata/routes_map.json"),
+		})
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		routesMap, err := c.CompactRoutesMap(false)
+		require.NoError(t, err)
+		assert.Equal(t, compact, routesMap)
+	})
+}
+
+// generateRoutesMap generates a synthetic indexed routes map JSON
+// with the given number of mints and routes per mint.
+func generateRoutesMap(mints, routesPerMint int) []byte {
+	rnd := rand.New(rand.NewSource(1))
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"mintKeys":[`)
+	for i := 0; i < mints; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `"%044d"`, i)
+	}
+	buf.WriteString(`],"indexedRouteMap":{`)
+	for i := 0; i < mints; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `"%d":[`, i)
+		for j := 0; j < routesPerMint; j++ {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(&buf, "%d", rnd.Intn(mints))
+		}
+		buf.WriteByte(']')
+	}
+	buf.WriteString(`}}`)
+
+	return buf.Bytes()
+}
+
+func BenchmarkRoutesMapDecode(b *testing.B) {
+	data := generateRoutesMap(5000, 200)
+
+	b.Run("IndexedRoutesMap", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			var m jupiter.IndexedRoutesMap
+			if err := json.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("CompactRoutesMap", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			if _, err := jupiter.DecodeCompactRoutesMap(bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
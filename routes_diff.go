@@ -0,0 +1,63 @@
+package jupiter
+
+// RouteEdge is a route between two mints.
+type RouteEdge struct {
+	InputMint  string `json:"inputMint"`
+	OutputMint string `json:"outputMint"`
+}
+
+// RoutesDiff is a difference between two routes indexes.
+type RoutesDiff struct {
+	AddedMints   []string    `json:"addedMints,omitempty"`   // mints present only in the new index
+	RemovedMints []string    `json:"removedMints,omitempty"` // mints present only in the old index
+	AddedEdges   []RouteEdge `json:"addedEdges,omitempty"`   // routes present only in the new index
+	RemovedEdges []RouteEdge `json:"removedEdges,omitempty"` // routes present only in the old index
+}
+
+// IsEmpty returns true if there is no difference.
+func (d RoutesDiff) IsEmpty() bool {
+	return len(d.AddedMints) == 0 && len(d.RemovedMints) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0
+}
+
+// DiffRoutes computes the difference between the old and the new routes indexes.
+// Mints are matched by their keys, so indexes may be ordered differently.
+// Either index may be nil, which is treated as an empty one.
+func DiffRoutes(old, new *RoutesIndex) RoutesDiff {
+	if old == nil {
+		old = &RoutesIndex{}
+	}
+	if new == nil {
+		new = &RoutesIndex{}
+	}
+
+	var diff RoutesDiff
+	diff.AddedMints, diff.AddedEdges = missingIn(old, new)
+	diff.RemovedMints, diff.RemovedEdges = missingIn(new, old)
+
+	return diff
+}
+
+// missingIn returns the mints and the edges of the index b which are missing in the index a.
+func missingIn(a, b *RoutesIndex) ([]string, []RouteEdge) {
+	var (
+		mints []string
+		edges []RouteEdge
+	)
+	for i, mint := range b.mints {
+		ai, ok := a.mintIndex[mint]
+		if !ok {
+			mints = append(mints, mint)
+		}
+		for _, out := range b.outIndexes(uint32(i)) {
+			outMint := b.mints[out]
+			if ok {
+				if ao, found := a.mintIndex[outMint]; found && a.hasEdge(ai, ao) {
+					continue
+				}
+			}
+			edges = append(edges, RouteEdge{InputMint: mint, OutputMint: outMint})
+		}
+	}
+	return mints, edges
+}
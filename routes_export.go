@@ -0,0 +1,175 @@
+package jupiter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type (
+	// GraphExportOptions configures export of the routes graph.
+	GraphExportOptions struct {
+		Center string        // mint to export the neighbourhood of (optional, the whole graph is exported if empty)
+		Hops   int           // size of the neighbourhood in hops, in any direction (default: 1)
+		Tokens TokenRegistry // registry to annotate mints with token symbols (optional)
+	}
+
+	// Graph is a node/edge representation of the routes graph.
+	Graph struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}
+
+	// GraphNode is a mint in the routes graph.
+	GraphNode struct {
+		ID     string `json:"id"`               // mint address
+		Symbol string `json:"symbol,omitempty"` // token symbol, if known
+	}
+
+	// GraphEdge is a route between two mints in the routes graph.
+	GraphEdge struct {
+		Source string `json:"source"` // input mint address
+		Target string `json:"target"` // output mint address
+	}
+)
+
+// Graph returns the node/edge representation of the routes graph,
+// optionally restricted to the neighbourhood of the given mint.
+func (idx *RoutesIndex) Graph(opts GraphExportOptions) (Graph, error) {
+	nodes, err := idx.exportNodes(opts)
+	if err != nil {
+		return Graph{}, err
+	}
+
+	g := Graph{Nodes: make([]GraphNode, 0, len(nodes)), Edges: []GraphEdge{}}
+	idx.walkExport(nodes, func(i uint32) {
+		g.Nodes = append(g.Nodes, GraphNode{ID: idx.mints[i], Symbol: tokenSymbol(opts.Tokens, idx.mints[i])})
+	}, func(in, out uint32) {
+		g.Edges = append(g.Edges, GraphEdge{Source: idx.mints[in], Target: idx.mints[out]})
+	})
+
+	return g, nil
+}
+
+// WriteGraphJSON writes the node/edge JSON representation of the routes graph to w.
+func (idx *RoutesIndex) WriteGraphJSON(w io.Writer, opts GraphExportOptions) error {
+	g, err := idx.Graph(opts)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(g); err != nil {
+		return fmt.Errorf("failed to write graph json: %w", err)
+	}
+	return nil
+}
+
+// WriteDOT writes the routes graph to w in the Graphviz DOT format.
+// Nodes are labeled with token symbols if the token registry is provided.
+func (idx *RoutesIndex) WriteDOT(w io.Writer, opts GraphExportOptions) error {
+	nodes, err := idx.exportNodes(opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph routes {")
+	idx.walkExport(nodes, func(i uint32) {
+		mint := idx.mints[i]
+		if symbol := tokenSymbol(opts.Tokens, mint); symbol != "" {
+			fmt.Fprintf(bw, "\t%s [label=%s];\n", strconv.Quote(mint), strconv.Quote(symbol))
+			return
+		}
+		fmt.Fprintf(bw, "\t%s;\n", strconv.Quote(mint))
+	}, func(in, out uint32) {
+		fmt.Fprintf(bw, "\t%s -> %s;\n", strconv.Quote(idx.mints[in]), strconv.Quote(idx.mints[out]))
+	})
+	fmt.Fprintln(bw, "}")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write graph dot: %w", err)
+	}
+	return nil
+}
+
+// exportNodes returns the set of mint indexes to export, or nil to export all the mints.
+func (idx *RoutesIndex) exportNodes(opts GraphExportOptions) (map[uint32]struct{}, error) {
+	if opts.Center == "" {
+		return nil, nil
+	}
+	center, ok := idx.mintIndex[opts.Center]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMint, opts.Center)
+	}
+	hops := opts.Hops
+	if hops <= 0 {
+		hops = 1
+	}
+
+	// Breadth-first search along routes in both directions.
+	nodes := map[uint32]struct{}{center: {}}
+	frontier := []uint32{center}
+	for hop := 0; hop < hops && len(frontier) > 0; hop++ {
+		var next []uint32
+		for _, i := range frontier {
+			for _, neighbours := range [][]uint32{idx.outIndexes(i), idx.inIndexes(i)} {
+				for _, j := range neighbours {
+					if _, seen := nodes[j]; !seen {
+						nodes[j] = struct{}{}
+						next = append(next, j)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return nodes, nil
+}
+
+// walkExport calls the node callback for every exported mint in the index order
+// and the edge callback for every route between exported mints.
+func (idx *RoutesIndex) walkExport(nodes map[uint32]struct{}, node func(uint32), edge func(in, out uint32)) {
+	included := func(i uint32) bool {
+		if nodes == nil {
+			return true
+		}
+		_, ok := nodes[i]
+		return ok
+	}
+
+	for i := range idx.mints {
+		if included(uint32(i)) {
+			node(uint32(i))
+		}
+	}
+	for i := range idx.mints {
+		if !included(uint32(i)) {
+			continue
+		}
+		for _, out := range idx.outIndexes(uint32(i)) {
+			if included(out) {
+				edge(uint32(i), out)
+			}
+		}
+	}
+}
+
+// WriteDOT writes the routes graph to w in the Graphviz DOT format, see RoutesIndex.WriteDOT.
+func (r *IndexedRoutesMap) WriteDOT(w io.Writer, opts GraphExportOptions) error {
+	idx, err := r.Index()
+	if err != nil {
+		return err
+	}
+	return idx.WriteDOT(w, opts)
+}
+
+// WriteGraphJSON writes the node/edge JSON representation of the routes graph to w, see RoutesIndex.WriteGraphJSON.
+func (r *IndexedRoutesMap) WriteGraphJSON(w io.Writer, opts GraphExportOptions) error {
+	idx, err := r.Index()
+	if err != nil {
+		return err
+	}
+	return idx.WriteGraphJSON(w, opts)
+}
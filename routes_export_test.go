@@ -0,0 +1,95 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesExport(t *testing.T) {
+	idx := loadRoutesIndex(t)
+	tokens := jupiter.NewTokenMap([]jupiter.Token{
+		{Address: "So11111111111111111111111111111111111111112", Symbol: "SOL", Decimals: 9},
+		{Address: "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", Symbol: "Bonk", Decimals: 5},
+	})
+	opts := jupiter.GraphExportOptions{
+		Center: "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263",
+		Hops:   1,
+		Tokens: tokens,
+	}
+
+	t.Run("graph", func(t *testing.T) {
+		g, err := idx.Graph(opts)
+		require.NoError(t, err)
+		assert.Equal(t, jupiter.Graph{
+			Nodes: []jupiter.GraphNode{
+				{ID: "So11111111111111111111111111111111111111112", Symbol: "SOL"},
+				{ID: "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", Symbol: "Bonk"},
+			},
+			Edges: []jupiter.GraphEdge{
+				{Source: "So11111111111111111111111111111111111111112", Target: "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"},
+			},
+		}, g)
+
+		opts := opts
+		opts.Hops = 2
+		g, err = idx.Graph(opts)
+		require.NoError(t, err)
+		assert.Len(t, g.Nodes, 5)
+		assert.Len(t, g.Edges, idx.NumRoutes())
+
+		_, err = idx.Graph(jupiter.GraphExportOptions{Center: "unknown"})
+		require.ErrorIs(t, err, jupiter.ErrUnknownMint)
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, idx.WriteDOT(&buf, opts))
+		assert.Equal(t, `digraph routes {
+	"So11111111111111111111111111111111111111112" [label="SOL"];
+	"DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263" [label="Bonk"];
+	"So11111111111111111111111111111111111111112" -> "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263";
+}
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, idx.WriteGraphJSON(&buf, jupiter.GraphExportOptions{}))
+		assert.Contains(t, buf.String(), `{"id":"mSoLzYCxHdYgdzU16g5QSh3i5K3z3KZK7ytfqcJm7So"}`)
+		assert.Contains(t, buf.String(), `{"source":"mSoLzYCxHdYgdzU16g5QSh3i5K3z3KZK7ytfqcJm7So","target":"So11111111111111111111111111111111111111112"}`)
+	})
+}
+
+func TestTokenList(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/strict": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"address":"So11111111111111111111111111111111111111112","chainId":101,"decimals":9,"name":"Wrapped SOL","symbol":"SOL","extensions":{}}]`))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithTokenListURL(srv.URL + "/strict"))
+
+	tokens, err := c.TokenList()
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	token, ok := jupiter.NewTokenMap(tokens).Token(wSolMint.String())
+	require.True(t, ok)
+	assert.Equal(t, "SOL", token.Symbol)
+	assert.Equal(t, uint8(9), token.Decimals)
+
+	var drifts []jupiter.SchemaDrift
+	c = jupiter.NewClient(
+		jupiter.WithTokenListURL(srv.URL+"/strict"),
+		jupiter.WithStrictDecoding(func(d jupiter.SchemaDrift) { drifts = append(drifts, d) }),
+	)
+	_, err = c.TokenList()
+	require.NoError(t, err)
+	assert.Equal(t, []jupiter.SchemaDrift{
+		{Kind: jupiter.DriftUnknownField, Type: "Token", Path: "[0].extensions", Detail: "value: {}"},
+	}, drifts)
+}
@@ -0,0 +1,232 @@
+package jupiter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// RoutesIndex is an indexed form of the routes map built once for fast lookups:
+// O(1) mint to index resolution, forward and reverse adjacency lists sorted by mint index,
+// degree counts and route existence checks.
+// It's immutable after construction, so it's safe for concurrent readers.
+type RoutesIndex struct {
+	mints     []string
+	mintIndex map[string]uint32
+
+	outOffsets []uint32 // CSR offsets of the output mints
+	outEdges   []uint32 // sorted output mint indexes per input mint
+	inOffsets  []uint32 // CSR offsets of the input mints
+	inEdges    []uint32 // sorted input mint indexes per output mint
+}
+
+// Compact converts the routes map into the compact CSR representation.
+// It returns ErrInvalidRoutesMap if the map references a mint index out of range.
+func (r *IndexedRoutesMap) Compact() (*CompactRoutesMap, error) {
+	rows := make([]routesRow, 0, len(r.IndexedRouteMap))
+	total := 0
+	for key, routes := range r.IndexedRouteMap {
+		mint, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid mint index %q", ErrInvalidRoutesMap, key)
+		}
+		edges := make([]uint32, len(routes))
+		for i, e := range routes {
+			if e < 0 || e >= len(r.MintKeys) {
+				return nil, fmt.Errorf("%w: output mint index %d out of range", ErrInvalidRoutesMap, e)
+			}
+			edges[i] = uint32(e)
+		}
+		rows = append(rows, routesRow{mint: uint32(mint), edges: edges})
+		total += len(edges)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].mint < rows[j].mint })
+
+	m := &CompactRoutesMap{MintKeys: r.MintKeys}
+	if err := m.build(rows, total); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Index builds the routes index from the routes map.
+func (r *IndexedRoutesMap) Index() (*RoutesIndex, error) {
+	m, err := r.Compact()
+	if err != nil {
+		return nil, err
+	}
+	return m.Index(), nil
+}
+
+// Index builds the routes index from the compact routes map.
+// Duplicate routes are dropped.
+func (m *CompactRoutesMap) Index() *RoutesIndex {
+	n := len(m.MintKeys)
+	idx := &RoutesIndex{
+		mints:      m.MintKeys,
+		mintIndex:  make(map[string]uint32, n),
+		outOffsets: make([]uint32, n+1),
+		outEdges:   make([]uint32, 0, len(m.Edges)),
+		inOffsets:  make([]uint32, n+1),
+	}
+	for i, mint := range m.MintKeys {
+		idx.mintIndex[mint] = uint32(i)
+	}
+
+	// Forward adjacency: sorted and deduplicated copy of every row.
+	for i := 0; i < n; i++ {
+		start := len(idx.outEdges)
+		idx.outEdges = append(idx.outEdges, m.RoutesForIndex(i)...)
+		row := idx.outEdges[start:]
+		sort.Slice(row, func(a, b int) bool { return row[a] < row[b] })
+		idx.outEdges = idx.outEdges[:start+len(dedupSorted(row))]
+		idx.outOffsets[i+1] = uint32(len(idx.outEdges))
+	}
+
+	// Reverse adjacency: counting sort of the forward edges by output mint.
+	// Input mints are visited in ascending order, so every reverse row is sorted.
+	for _, out := range idx.outEdges {
+		idx.inOffsets[out+1]++
+	}
+	for i := 0; i < n; i++ {
+		idx.inOffsets[i+1] += idx.inOffsets[i]
+	}
+	idx.inEdges = make([]uint32, len(idx.outEdges))
+	next := make([]uint32, n)
+	copy(next, idx.inOffsets[:n])
+	for in := 0; in < n; in++ {
+		for _, out := range idx.outIndexes(uint32(in)) {
+			idx.inEdges[next[out]] = uint32(in)
+			next[out]++
+		}
+	}
+
+	return idx
+}
+
+// dedupSorted removes consecutive duplicates from the sorted slice in place.
+func dedupSorted(s []uint32) []uint32 {
+	if len(s) < 2 {
+		return s
+	}
+	j := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[j-1] {
+			s[j] = s[i]
+			j++
+		}
+	}
+	return s[:j]
+}
+
+// Len returns the number of indexed mints.
+func (idx *RoutesIndex) Len() int {
+	return len(idx.mints)
+}
+
+// NumRoutes returns the total number of routes, i.e. edges between mints.
+func (idx *RoutesIndex) NumRoutes() int {
+	return len(idx.outEdges)
+}
+
+// Mints returns all the indexed mints, the position of a mint is its index.
+// The returned slice shares memory with the index and must not be modified.
+func (idx *RoutesIndex) Mints() []string {
+	return idx.mints
+}
+
+// Mint returns the mint with the given index, or an empty string if the index is out of range.
+func (idx *RoutesIndex) Mint(i uint32) string {
+	if int(i) >= len(idx.mints) {
+		return ""
+	}
+	return idx.mints[i]
+}
+
+// MintIndex returns the index of the given mint.
+func (idx *RoutesIndex) MintIndex(mint string) (uint32, bool) {
+	i, ok := idx.mintIndex[mint]
+	return i, ok
+}
+
+// outIndexes returns the sorted output mint indexes of the input mint with the given index.
+func (idx *RoutesIndex) outIndexes(i uint32) []uint32 {
+	return idx.outEdges[idx.outOffsets[i]:idx.outOffsets[i+1]]
+}
+
+// inIndexes returns the sorted input mint indexes of the output mint with the given index.
+func (idx *RoutesIndex) inIndexes(i uint32) []uint32 {
+	return idx.inEdges[idx.inOffsets[i]:idx.inOffsets[i+1]]
+}
+
+// GetRoutesForMint returns the mints the given mint can be swapped into.
+// It's an alias for Outputs, matching IndexedRoutesMap.GetRoutesForMint.
+func (idx *RoutesIndex) GetRoutesForMint(mint string) []string {
+	return idx.Outputs(mint)
+}
+
+// Outputs returns the mints the given mint can be swapped into.
+func (idx *RoutesIndex) Outputs(mint string) []string {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return []string{}
+	}
+	return idx.resolve(idx.outIndexes(i))
+}
+
+// Inputs returns the mints which can be swapped into the given mint.
+func (idx *RoutesIndex) Inputs(mint string) []string {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return []string{}
+	}
+	return idx.resolve(idx.inIndexes(i))
+}
+
+// OutDegree returns the number of mints the given mint can be swapped into.
+func (idx *RoutesIndex) OutDegree(mint string) int {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return 0
+	}
+	return int(idx.outOffsets[i+1] - idx.outOffsets[i])
+}
+
+// InDegree returns the number of mints which can be swapped into the given mint.
+func (idx *RoutesIndex) InDegree(mint string) int {
+	i, ok := idx.mintIndex[mint]
+	if !ok {
+		return 0
+	}
+	return int(idx.inOffsets[i+1] - idx.inOffsets[i])
+}
+
+// HasRoute returns true if the input mint can be swapped into the output mint.
+func (idx *RoutesIndex) HasRoute(inputMint, outputMint string) bool {
+	in, ok := idx.mintIndex[inputMint]
+	if !ok {
+		return false
+	}
+	out, ok := idx.mintIndex[outputMint]
+	if !ok {
+		return false
+	}
+	return idx.hasEdge(in, out)
+}
+
+// hasEdge returns true if there is a route between the mints with the given indexes.
+func (idx *RoutesIndex) hasEdge(in, out uint32) bool {
+	row := idx.outIndexes(in)
+	i := sort.Search(len(row), func(i int) bool { return row[i] >= out })
+	return i < len(row) && row[i] == out
+}
+
+// resolve converts mint indexes into mints.
+func (idx *RoutesIndex) resolve(indexes []uint32) []string {
+	result := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		result = append(result, idx.mints[i])
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package jupiter_test
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadRoutesIndex loads the routes index from the test fixture.
+func loadRoutesIndex(t testing.TB) *jupiter.RoutesIndex {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/routes_map.json")
+	require.NoError(t, err)
+
+	var routesMap jupiter.IndexedRoutesMap
+	require.NoError(t, json.Unmarshal(data, &routesMap))
+
+	idx, err := routesMap.Index()
+	require.NoError(t, err)
+
+	return idx
+}
+
+func TestRoutesIndex(t *testing.T) {
+	const (
+		sol  = "So11111111111111111111111111111111111111112"
+		usdc = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+		usdt = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+		msol = "mSoLzYCxHdYgdzU16g5QSh3i5K3z3KZK7ytfqcJm7So"
+		bonk = "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"
+	)
+
+	idx := loadRoutesIndex(t)
+	assert.Equal(t, 5, idx.Len())
+	assert.Equal(t, 11, idx.NumRoutes())
+
+	i, ok := idx.MintIndex(usdt)
+	require.True(t, ok)
+	assert.Equal(t, uint32(2), i)
+	assert.Equal(t, usdt, idx.Mint(i))
+	_, ok = idx.MintIndex("unknown")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{usdc, usdt, msol, bonk}, idx.Outputs(sol))
+	assert.Equal(t, idx.Outputs(sol), idx.GetRoutesForMint(sol))
+	assert.Equal(t, []string{sol}, idx.Inputs(bonk))
+	assert.Equal(t, []string{}, idx.Outputs(bonk))
+	assert.Equal(t, []string{sol, usdt, msol}, idx.Inputs(usdc))
+
+	assert.Equal(t, 4, idx.OutDegree(sol))
+	assert.Equal(t, 3, idx.InDegree(sol))
+	assert.Equal(t, 0, idx.OutDegree("unknown"))
+
+	assert.True(t, idx.HasRoute(sol, bonk))
+	assert.False(t, idx.HasRoute(bonk, sol))
+	assert.False(t, idx.HasRoute(usdt, msol))
+	assert.False(t, idx.HasRoute("unknown", sol))
+
+	t.Run("concurrent readers", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					assert.True(t, idx.HasRoute(usdc, sol))
+					assert.Len(t, idx.Inputs(usdc), 3)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("invalid map", func(t *testing.T) {
+		_, err := (&jupiter.IndexedRoutesMap{
+			MintKeys:        []string{sol},
+			IndexedRouteMap: map[string][]int{"0": {1}},
+		}).Index()
+		require.ErrorIs(t, err, jupiter.ErrInvalidRoutesMap)
+	})
+}
@@ -0,0 +1,221 @@
+package jupiter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Routes snapshot file format, all integers are unsigned varints:
+//
+//	magic "JRMS", version byte
+//	number of mints, then length-prefixed mint keys
+//	for every mint: number of output mints, then delta-encoded sorted output mint indexes
+//	CRC-32 (IEEE) of all the preceding bytes, 4 bytes big-endian
+const (
+	snapshotMagic   = "JRMS"
+	snapshotVersion = 1
+)
+
+// ErrInvalidSnapshot is returned when a routes snapshot is malformed or corrupted.
+var ErrInvalidSnapshot = errors.New("invalid routes snapshot")
+
+// WriteSnapshot writes the routes index to w in the compact binary snapshot format.
+func (idx *RoutesIndex) WriteSnapshot(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	crc := crc32.NewIEEE()
+	out := io.MultiWriter(bw, crc)
+
+	var buf [binary.MaxVarintLen64]byte
+	writeUvarint := func(v uint64) error {
+		_, err := out.Write(buf[:binary.PutUvarint(buf[:], v)])
+		return err
+	}
+
+	if _, err := io.WriteString(out, snapshotMagic); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	if _, err := out.Write([]byte{snapshotVersion}); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	if err := writeUvarint(uint64(len(idx.mints))); err != nil {
+		return fmt.Errorf("failed to write snapshot mints: %w", err)
+	}
+	for _, mint := range idx.mints {
+		if err := writeUvarint(uint64(len(mint))); err != nil {
+			return fmt.Errorf("failed to write snapshot mints: %w", err)
+		}
+		if _, err := io.WriteString(out, mint); err != nil {
+			return fmt.Errorf("failed to write snapshot mints: %w", err)
+		}
+	}
+
+	for i := range idx.mints {
+		row := idx.outIndexes(uint32(i))
+		if err := writeUvarint(uint64(len(row))); err != nil {
+			return fmt.Errorf("failed to write snapshot routes: %w", err)
+		}
+		prev := uint32(0)
+		for _, e := range row {
+			if err := writeUvarint(uint64(e - prev)); err != nil {
+				return fmt.Errorf("failed to write snapshot routes: %w", err)
+			}
+			prev = e
+		}
+	}
+
+	if err := binary.Write(bw, binary.BigEndian, crc.Sum32()); err != nil {
+		return fmt.Errorf("failed to write snapshot checksum: %w", err)
+	}
+
+	return bw.Flush()
+}
+
+// ReadRoutesSnapshot reads the routes index from the snapshot written by RoutesIndex.WriteSnapshot.
+func ReadRoutesSnapshot(r io.Reader) (*RoutesIndex, error) {
+	crc := crc32.NewIEEE()
+	br := &snapshotReader{r: bufio.NewReader(r), crc: crc}
+
+	header := make([]byte, len(snapshotMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidSnapshot, err)
+	}
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidSnapshot)
+	}
+	if header[len(snapshotMagic)] != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, header[len(snapshotMagic)])
+	}
+
+	n, err := br.readUvarint(1 << 24)
+	if err != nil {
+		return nil, err
+	}
+	// The slices grow as the mints are read rather than sized from the unverified mint count,
+	// so a corrupted snapshot can't make the reader allocate for mints which aren't there.
+	m := &CompactRoutesMap{Offsets: []uint32{0}}
+	for i := uint64(0); i < n; i++ {
+		size, err := br.readUvarint(1 << 16)
+		if err != nil {
+			return nil, err
+		}
+		mint := make([]byte, size)
+		if _, err := io.ReadFull(br, mint); err != nil {
+			return nil, fmt.Errorf("%w: failed to read mint: %v", ErrInvalidSnapshot, err)
+		}
+		m.MintKeys = append(m.MintKeys, string(mint))
+	}
+
+	for i := uint64(0); i < n; i++ {
+		count, err := br.readUvarint(n)
+		if err != nil {
+			return nil, err
+		}
+		prev := uint64(0)
+		for j := uint64(0); j < count; j++ {
+			delta, err := br.readUvarint(n)
+			if err != nil {
+				return nil, err
+			}
+			prev += delta
+			if prev >= n {
+				return nil, fmt.Errorf("%w: mint index %d out of range", ErrInvalidSnapshot, prev)
+			}
+			m.Edges = append(m.Edges, uint32(prev))
+		}
+		m.Offsets = append(m.Offsets, uint32(len(m.Edges)))
+	}
+
+	sum := crc.Sum32()
+	var stored uint32
+	if err := binary.Read(br.r, binary.BigEndian, &stored); err != nil {
+		return nil, fmt.Errorf("%w: failed to read checksum: %v", ErrInvalidSnapshot, err)
+	}
+	if stored != sum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	return m.Index(), nil
+}
+
+// snapshotReader reads the snapshot and computes its checksum on the fly.
+type snapshotReader struct {
+	r   *bufio.Reader
+	crc io.Writer
+}
+
+// Read implements io.Reader.
+func (s *snapshotReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	_, _ = s.crc.Write(p[:n])
+	return n, err
+}
+
+// ReadByte implements io.ByteReader.
+func (s *snapshotReader) ReadByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == nil {
+		_, _ = s.crc.Write([]byte{b})
+	}
+	return b, err
+}
+
+// readUvarint reads an unsigned varint which must not exceed the given limit.
+func (s *snapshotReader) readUvarint(limit uint64) (uint64, error) {
+	v, err := binary.ReadUvarint(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if v > limit {
+		return 0, fmt.Errorf("%w: value %d exceeds %d", ErrInvalidSnapshot, v, limit)
+	}
+	return v, nil
+}
+
+// SaveSnapshot atomically writes the routes index snapshot to the file at the given path.
+func (idx *RoutesIndex) SaveSnapshot(path string) error {
+	if err := writeFileAtomic(path, idx.WriteSnapshot); err != nil {
+		return fmt.Errorf("failed to save snapshot file: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the file at the given path with write through a temporary file,
+// so readers never see a partially written file.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadRoutesSnapshot reads the routes index from the snapshot file at the given path.
+func LoadRoutesSnapshot(path string) (*RoutesIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	return ReadRoutesSnapshot(f)
+}
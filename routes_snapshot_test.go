@@ -0,0 +1,98 @@
+package jupiter_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesSnapshot(t *testing.T) {
+	idx := loadRoutesIndex(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, idx.WriteSnapshot(&buf))
+
+	loaded, err := jupiter.ReadRoutesSnapshot(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, idx.Mints(), loaded.Mints())
+	for _, mint := range idx.Mints() {
+		assert.Equal(t, idx.Outputs(mint), loaded.Outputs(mint))
+		assert.Equal(t, idx.Inputs(mint), loaded.Inputs(mint))
+	}
+	assert.True(t, jupiter.DiffRoutes(idx, loaded).IsEmpty())
+
+	t.Run("corrupted", func(t *testing.T) {
+		data := append([]byte(nil), buf.Bytes()...)
+		data[len(data)-6] ^= 0xff
+		_, err := jupiter.ReadRoutesSnapshot(bytes.NewReader(data))
+		require.ErrorIs(t, err, jupiter.ErrInvalidSnapshot)
+
+		_, err = jupiter.ReadRoutesSnapshot(bytes.NewReader(buf.Bytes()[:10]))
+		require.ErrorIs(t, err, jupiter.ErrInvalidSnapshot)
+	})
+
+	t.Run("truncated with huge mint count", func(t *testing.T) {
+		data := binary.AppendUvarint([]byte("JRMS\x01"), 1<<24)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := jupiter.ReadRoutesSnapshot(bytes.NewReader(data))
+		runtime.ReadMemStats(&after)
+
+		require.ErrorIs(t, err, jupiter.ErrInvalidSnapshot)
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "routes.snapshot")
+		require.NoError(t, idx.SaveSnapshot(path))
+
+		loaded, err := jupiter.LoadRoutesSnapshot(path)
+		require.NoError(t, err)
+		assert.True(t, jupiter.DiffRoutes(idx, loaded).IsEmpty())
+	})
+}
+
+func TestDiffRoutes(t *testing.T) {
+	old, err := (&jupiter.IndexedRoutesMap{
+		MintKeys:        []string{"a", "b", "c"},
+		IndexedRouteMap: map[string][]int{"0": {1, 2}, "1": {0}, "2": {0}},
+	}).Index()
+	require.NoError(t, err)
+
+	// Mints are reordered, "c" is delisted, "d" is listed, route b->a is removed, route a->d is added.
+	new, err := (&jupiter.IndexedRoutesMap{
+		MintKeys:        []string{"d", "b", "a"},
+		IndexedRouteMap: map[string][]int{"2": {1, 0}, "0": {2}},
+	}).Index()
+	require.NoError(t, err)
+
+	diff := jupiter.DiffRoutes(old, new)
+	assert.Equal(t, jupiter.RoutesDiff{
+		AddedMints:   []string{"d"},
+		RemovedMints: []string{"c"},
+		AddedEdges: []jupiter.RouteEdge{
+			{InputMint: "d", OutputMint: "a"},
+			{InputMint: "a", OutputMint: "d"},
+		},
+		RemovedEdges: []jupiter.RouteEdge{
+			{InputMint: "a", OutputMint: "c"},
+			{InputMint: "b", OutputMint: "a"},
+			{InputMint: "c", OutputMint: "a"},
+		},
+	}, diff)
+	assert.False(t, diff.IsEmpty())
+
+	t.Run("from nothing", func(t *testing.T) {
+		diff := jupiter.DiffRoutes(nil, old)
+		assert.Equal(t, []string{"a", "b", "c"}, diff.AddedMints)
+		assert.Len(t, diff.AddedEdges, 4)
+		assert.Empty(t, diff.RemovedEdges)
+	})
+}
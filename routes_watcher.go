@@ -0,0 +1,266 @@
+package jupiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RoutesEventType is a type of routes change event.
+type RoutesEventType string
+
+// Predefined routes event types.
+const (
+	RoutesEventMintListed   RoutesEventType = "mint_listed"   // a new mint became tradable
+	RoutesEventMintDelisted RoutesEventType = "mint_delisted" // a mint is no longer tradable
+	RoutesEventEdgeAdded    RoutesEventType = "edge_added"    // a new route between two mints appeared
+	RoutesEventEdgeRemoved  RoutesEventType = "edge_removed"  // a route between two mints disappeared
+)
+
+type (
+	// RoutesEvent is a change of the routes map detected by RoutesWatcher.
+	RoutesEvent struct {
+		Type RoutesEventType `json:"type"`
+		Mint string          `json:"mint,omitempty"` // listed or delisted mint
+		Edge RouteEdge       `json:"edge"`           // added or removed route
+		At   time.Time       `json:"at"`             // time the change was detected
+	}
+
+	// RoutesWatcher periodically refreshes the routes map in the background,
+	// atomically swaps in the new version for readers and publishes change events to subscribers.
+	RoutesWatcher struct {
+		client           *Client
+		interval         time.Duration
+		onlyDirectRoutes bool
+		errorHandler     func(error)
+
+		current   atomic.Pointer[RoutesIndex]
+		refreshMu sync.Mutex
+
+		mu          sync.Mutex
+		subscribers map[*routesSubscriber]struct{}
+		closed      bool
+	}
+
+	// routesSubscriber is a subscriber of the routes watcher.
+	routesSubscriber struct {
+		ch   chan RoutesEvent
+		done chan struct{} // closed on unsubscribe to unblock the publisher
+		once sync.Once
+
+		mu     sync.Mutex // guards sending to ch and closing it
+		closed bool
+	}
+
+	// RoutesWatcherOption is a function that can be used to configure a routes watcher.
+	RoutesWatcherOption func(*RoutesWatcher)
+)
+
+// NewRoutesWatcher returns a new routes watcher using the given client.
+// Call Run to start refreshing.
+func NewRoutesWatcher(client *Client, opts ...RoutesWatcherOption) *RoutesWatcher {
+	w := &RoutesWatcher{
+		client:      client,
+		interval:    5 * time.Minute,
+		subscribers: make(map[*routesSubscriber]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// WithWatcherInterval returns a RoutesWatcherOption that configures the refresh interval. Default: 5 minutes.
+// Non-positive intervals are ignored.
+func WithWatcherInterval(interval time.Duration) RoutesWatcherOption {
+	return func(w *RoutesWatcher) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithWatcherOnlyDirectRoutes returns a RoutesWatcherOption that makes the watcher track only direct routes.
+func WithWatcherOnlyDirectRoutes(onlyDirectRoutes bool) RoutesWatcherOption {
+	return func(w *RoutesWatcher) {
+		w.onlyDirectRoutes = onlyDirectRoutes
+	}
+}
+
+// WithWatcherInitialIndex returns a RoutesWatcherOption that seeds the watcher with the given routes index,
+// e.g. loaded from a snapshot, so readers don't depend on the first refresh
+// and changes since the snapshot are published as events.
+func WithWatcherInitialIndex(idx *RoutesIndex) RoutesWatcherOption {
+	return func(w *RoutesWatcher) {
+		w.current.Store(idx)
+	}
+}
+
+// WithWatcherErrorHandler returns a RoutesWatcherOption that configures the handler of refresh errors.
+// Failed refreshes keep the current routes index.
+func WithWatcherErrorHandler(handler func(error)) RoutesWatcherOption {
+	return func(w *RoutesWatcher) {
+		w.errorHandler = handler
+	}
+}
+
+// Current returns the current routes index, or nil if the routes map has not been loaded yet.
+func (w *RoutesWatcher) Current() *RoutesIndex {
+	return w.current.Load()
+}
+
+// Subscribe returns a channel of routes change events with the given buffer size
+// and a function to unsubscribe. The channel is closed on unsubscribe or when the watcher stops.
+// Events are delivered in order; subscribers must drain the channel, since a full channel blocks the refresh.
+func (w *RoutesWatcher) Subscribe(buffer int) (<-chan RoutesEvent, func()) {
+	sub := &routesSubscriber{
+		ch:   make(chan RoutesEvent, buffer),
+		done: make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		sub.close()
+		return sub.ch, func() {}
+	}
+	w.subscribers[sub] = struct{}{}
+
+	return sub.ch, func() {
+		w.mu.Lock()
+		delete(w.subscribers, sub)
+		w.mu.Unlock()
+
+		sub.close()
+	}
+}
+
+// Run refreshes the routes map immediately and then on every interval until the context is canceled.
+// On return all the subscriber channels are closed. It returns nil when stopped by the context.
+func (w *RoutesWatcher) Run(ctx context.Context) error {
+	defer w.close()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Refresh(ctx); err != nil && ctx.Err() == nil && w.errorHandler != nil {
+			w.errorHandler(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the routes map, swaps it in and publishes the changes to subscribers.
+// Changes are not published for the very first load, unless the watcher was seeded with an initial index.
+func (w *RoutesWatcher) Refresh(ctx context.Context) error {
+	w.refreshMu.Lock()
+	defer w.refreshMu.Unlock()
+
+	m, err := w.client.compactRoutesMap(ctx, w.onlyDirectRoutes)
+	if err != nil {
+		return err
+	}
+	idx := m.Index()
+
+	old := w.current.Swap(idx)
+	if old == nil {
+		return nil
+	}
+
+	w.publish(ctx, DiffRoutes(old, idx))
+
+	return nil
+}
+
+// publish sends the changes to all the subscribers.
+func (w *RoutesWatcher) publish(ctx context.Context, diff RoutesDiff) {
+	if diff.IsEmpty() {
+		return
+	}
+
+	at := w.client.now()
+	events := make([]RoutesEvent, 0, len(diff.AddedMints)+len(diff.RemovedMints)+len(diff.AddedEdges)+len(diff.RemovedEdges))
+	for _, mint := range diff.AddedMints {
+		events = append(events, RoutesEvent{Type: RoutesEventMintListed, Mint: mint, At: at})
+	}
+	for _, mint := range diff.RemovedMints {
+		events = append(events, RoutesEvent{Type: RoutesEventMintDelisted, Mint: mint, At: at})
+	}
+	for _, edge := range diff.AddedEdges {
+		events = append(events, RoutesEvent{Type: RoutesEventEdgeAdded, Edge: edge, At: at})
+	}
+	for _, edge := range diff.RemovedEdges {
+		events = append(events, RoutesEvent{Type: RoutesEventEdgeRemoved, Edge: edge, At: at})
+	}
+
+	// Subscribers are sent to without holding the lock, so a slow subscriber
+	// doesn't block subscribing and unsubscribing.
+	w.mu.Lock()
+	subs := make([]*routesSubscriber, 0, len(w.subscribers))
+	for sub := range w.subscribers {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.send(ctx, events) {
+			return
+		}
+	}
+}
+
+// close closes all the subscriber channels.
+func (w *RoutesWatcher) close() {
+	w.mu.Lock()
+	w.closed = true
+	subs := w.subscribers
+	w.subscribers = make(map[*routesSubscriber]struct{})
+	w.mu.Unlock()
+
+	for sub := range subs {
+		sub.close()
+	}
+}
+
+// send sends the events in order until the subscriber unsubscribes.
+// It returns false if the context is done.
+func (s *routesSubscriber) send(ctx context.Context, events []RoutesEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+	for _, e := range events {
+		select {
+		case s.ch <- e:
+		case <-s.done:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// close unblocks the publisher and closes the event channel, it's safe to call multiple times.
+func (s *routesSubscriber) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.ch)
+	})
+}
@@ -0,0 +1,122 @@
+package jupiter_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutesWatcher(t *testing.T) {
+	versions := []string{
+		`{"mintKeys":["a","b","c"],"indexedRouteMap":{"0":[1,2],"1":[0],"2":[0]}}`,
+		`{"mintKeys":["a","b","d"],"indexedRouteMap":{"0":[1,2],"1":[0]}}`,
+	}
+	var version atomic.Int32
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/indexed-route-map": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(versions[version.Load()]))
+		},
+	})
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	t.Run("refresh", func(t *testing.T) {
+		version.Store(0)
+		w := jupiter.NewRoutesWatcher(c)
+		assert.Nil(t, w.Current())
+
+		events, unsubscribe := w.Subscribe(10)
+		defer unsubscribe()
+
+		require.NoError(t, w.Refresh(context.Background()))
+		require.NotNil(t, w.Current())
+		assert.True(t, w.Current().HasRoute("a", "c"))
+		assert.Empty(t, events, "no events expected on the first load")
+
+		version.Store(1)
+		require.NoError(t, w.Refresh(context.Background()))
+		assert.True(t, w.Current().HasRoute("a", "d"))
+
+		var got []jupiter.RoutesEvent
+		for len(events) > 0 {
+			e := <-events
+			e.At = time.Time{}
+			got = append(got, e)
+		}
+		assert.Equal(t, []jupiter.RoutesEvent{
+			{Type: jupiter.RoutesEventMintListed, Mint: "d"},
+			{Type: jupiter.RoutesEventMintDelisted, Mint: "c"},
+			{Type: jupiter.RoutesEventEdgeAdded, Edge: jupiter.RouteEdge{InputMint: "a", OutputMint: "d"}},
+			{Type: jupiter.RoutesEventEdgeRemoved, Edge: jupiter.RouteEdge{InputMint: "a", OutputMint: "c"}},
+			{Type: jupiter.RoutesEventEdgeRemoved, Edge: jupiter.RouteEdge{InputMint: "c", OutputMint: "a"}},
+		}, got)
+	})
+
+	t.Run("run until canceled", func(t *testing.T) {
+		version.Store(1)
+		initial, err := (&jupiter.IndexedRoutesMap{
+			MintKeys:        []string{"a", "b", "c"},
+			IndexedRouteMap: map[string][]int{"0": {1, 2}, "1": {0}, "2": {0}},
+		}).Index()
+		require.NoError(t, err)
+
+		w := jupiter.NewRoutesWatcher(c,
+			jupiter.WithWatcherInterval(10*time.Millisecond),
+			jupiter.WithWatcherInitialIndex(initial),
+		)
+		events, _ := w.Subscribe(0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- w.Run(ctx) }()
+
+		e := <-events
+		assert.Equal(t, jupiter.RoutesEventMintListed, e.Type)
+		assert.Equal(t, "d", e.Mint)
+
+		cancel()
+		require.NoError(t, <-done)
+
+		// The channel is closed once the watcher stops.
+		for range events {
+		}
+	})
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		initial, err := (&jupiter.IndexedRoutesMap{
+			MintKeys:        []string{"a", "b", "c"},
+			IndexedRouteMap: map[string][]int{"0": {1, 2}, "1": {0}, "2": {0}},
+		}).Index()
+		require.NoError(t, err)
+
+		version.Store(1)
+		w := jupiter.NewRoutesWatcher(c, jupiter.WithWatcherInitialIndex(initial))
+		slow, unsubscribeSlow := w.Subscribe(0)
+
+		refreshed := make(chan error)
+		go func() { refreshed <- w.Refresh(context.Background()) }()
+		<-slow // the publisher is now blocked on the next event
+
+		// Subscribing and unsubscribing are not blocked by the publisher.
+		_, unsubscribe := w.Subscribe(0)
+		unsubscribe()
+		unsubscribeSlow()
+
+		require.NoError(t, <-refreshed)
+		for range slow {
+		}
+	})
+
+	t.Run("non-positive interval", func(t *testing.T) {
+		w := jupiter.NewRoutesWatcher(c, jupiter.WithWatcherInterval(0))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, w.Run(ctx))
+	})
+}
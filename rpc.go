@@ -0,0 +1,133 @@
+package jupiter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// rpcRequest is a Solana JSON-RPC request.
+	rpcRequest struct {
+		JSONRPC string        `json:"jsonrpc"`
+		ID      int           `json:"id"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}
+
+	// rpcResponse is a Solana JSON-RPC response.
+	rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+
+	// RPCError is an error returned by the Solana JSON-RPC endpoint.
+	RPCError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// tokenAccounts is the jsonParsed result of getTokenAccountsByOwner.
+	tokenAccounts struct {
+		Value []struct {
+			Account struct {
+				Data struct {
+					Parsed struct {
+						Info struct {
+							TokenAmount struct {
+								Amount string `json:"amount"`
+							} `json:"tokenAmount"`
+						} `json:"info"`
+					} `json:"parsed"`
+				} `json:"data"`
+			} `json:"account"`
+		} `json:"value"`
+	}
+)
+
+// Error implements the error interface.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// rpc calls the JSON-RPC method on the configured RPC endpoint and decodes the result into v.
+func (c *Client) rpc(ctx context.Context, method string, params []interface{}, v interface{}) error {
+	if c.rpcEndpoint == "" {
+		return ErrNoRPCEndpoint
+	}
+
+	resp, err := c.postURL(ctx, c.rpcEndpoint, rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to make %s request: %w", method, err)
+	}
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", method, err)
+	}
+
+	var response rpcResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, response.Error)
+	}
+
+	if err := json.Unmarshal(response.Result, v); err != nil {
+		return fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// SOLBalance returns the native SOL balance of the wallet in lamports.
+// It requires the RPC endpoint, see WithRPCEndpoint.
+func (c *Client) SOLBalance(owner PublicKey) (uint64, error) {
+	return c.solBalance(context.Background(), owner)
+}
+
+// solBalance returns the native SOL balance of the wallet in lamports.
+func (c *Client) solBalance(ctx context.Context, owner PublicKey) (uint64, error) {
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	if err := c.rpc(ctx, "getBalance", []interface{}{owner.String()}, &result); err != nil {
+		return 0, err
+	}
+	return result.Value, nil
+}
+
+// TokenBalance returns the total balance of all the wallet token accounts of the mint in base units.
+// It requires the RPC endpoint, see WithRPCEndpoint.
+func (c *Client) TokenBalance(owner PublicKey, mint Mint) (uint64, error) {
+	return c.tokenBalance(context.Background(), owner, mint)
+}
+
+// tokenBalance returns the total balance of all the wallet token accounts of the mint in base units.
+func (c *Client) tokenBalance(ctx context.Context, owner PublicKey, mint Mint) (uint64, error) {
+	var result tokenAccounts
+	if err := c.rpc(ctx, "getTokenAccountsByOwner", []interface{}{
+		owner.String(),
+		map[string]string{"mint": mint.String()},
+		map[string]string{"encoding": "jsonParsed"},
+	}, &result); err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, acc := range result.Value {
+		amount, err := parseUint(acc.Account.Data.Parsed.Info.TokenAmount.Amount)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse token account amount: %w", err)
+		}
+		total += amount
+	}
+
+	return total, nil
+}
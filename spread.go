@@ -0,0 +1,157 @@
+package jupiter
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+type (
+	// SpreadParams are the parameters of the two-sided price of the pair.
+	SpreadParams struct {
+		Base          Mint   // base token mint
+		Quote         Mint   // quote token mint
+		BaseAmount    uint64 // amount of base token sold to get the bid
+		QuoteAmount   uint64 // amount of quote token sold to get the ask, required to quote both sides concurrently (optional, default: the quote amount received for BaseAmount)
+		BaseDecimals  *uint8 // base token decimals, overrides the token registry (optional)
+		QuoteDecimals *uint8 // quote token decimals, overrides the token registry (optional)
+	}
+
+	// Spread is the two-sided price of the pair in quote token per one base token.
+	Spread struct {
+		Base      Mint     `json:"base"`      // base token mint
+		Quote     Mint     `json:"quote"`     // quote token mint
+		Bid       *big.Rat `json:"bid"`       // price of selling base token
+		Ask       *big.Rat `json:"ask"`       // price of buying base token
+		Mid       *big.Rat `json:"mid"`       // average of bid and ask
+		SpreadBps float64  `json:"spreadBps"` // difference between ask and bid relative to mid, in basis points
+		BidRate   Rate     `json:"bidRate"`   // ExactIn rate of base to quote
+		AskRate   Rate     `json:"askRate"`   // ExactIn rate of quote to base
+	}
+)
+
+// Spread returns bid, ask, mid and spread of the pair.
+// Both directions are quoted with ExactIn: base to quote for the bid, quote to base for the ask.
+// The directions are quoted concurrently only if QuoteAmount is set. Otherwise the ask is quoted
+// after the bid, selling the quote amount received on the bid side, so both sides have the same
+// notional at the cost of two sequential requests. To quote both directions concurrently,
+// set QuoteAmount, e.g. to the bid side amount of a recent spread of the pair.
+// Token decimals are taken from the params or resolved from the token registry, see WithTokenRegistry.
+func (c *Client) Spread(params SpreadParams) (Spread, error) {
+	result := Spread{Base: params.Base, Quote: params.Quote}
+	if err := params.Validate(); err != nil {
+		return result, fmt.Errorf("invalid spread params: %w", err)
+	}
+
+	bid := func() (err error) {
+		result.BidRate, err = c.ExchangeRate(ExchangeRateParams{
+			InputMint:      params.Base,
+			OutputMint:     params.Quote,
+			Amount:         params.BaseAmount,
+			SwapMode:       SwapModeExactIn,
+			InputDecimals:  params.BaseDecimals,
+			OutputDecimals: params.QuoteDecimals,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get bid: %w", err)
+		}
+		return nil
+	}
+	ask := func(amount uint64) (err error) {
+		result.AskRate, err = c.ExchangeRate(ExchangeRateParams{
+			InputMint:      params.Quote,
+			OutputMint:     params.Base,
+			Amount:         amount,
+			SwapMode:       SwapModeExactIn,
+			InputDecimals:  params.QuoteDecimals,
+			OutputDecimals: params.BaseDecimals,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get ask: %w", err)
+		}
+		return nil
+	}
+
+	if params.QuoteAmount == 0 {
+		if err := bid(); err != nil {
+			return result, err
+		}
+		if result.BidRate.OutAmount == 0 {
+			return result, fmt.Errorf("%w: bid side returned no quote token", ErrNoRoute)
+		}
+		if err := ask(result.BidRate.OutAmount); err != nil {
+			return result, err
+		}
+	} else {
+		var bidErr, askErr error
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			bidErr = bid()
+		}()
+		go func() {
+			defer wg.Done()
+			askErr = ask(params.QuoteAmount)
+		}()
+		wg.Wait()
+
+		if bidErr != nil {
+			return result, bidErr
+		}
+		if askErr != nil {
+			return result, askErr
+		}
+	}
+
+	result.Bid = result.BidRate.Price()
+	result.Ask = result.AskRate.InversePrice()
+	if result.Bid == nil || result.Ask == nil {
+		return result, fmt.Errorf("%w: decimals of %s or %s are unknown", ErrUnknownMint, params.Base, params.Quote)
+	}
+
+	result.Mid = new(big.Rat).Add(result.Bid, result.Ask)
+	result.Mid.Quo(result.Mid, big.NewRat(2, 1))
+	if result.Mid.Sign() > 0 {
+		spread := new(big.Rat).Sub(result.Ask, result.Bid)
+		spread.Mul(spread, big.NewRat(MaxBps, 1))
+		result.SpreadBps, _ = spread.Quo(spread, result.Mid).Float64()
+	}
+
+	return result, nil
+}
+
+// Spreads returns the spreads of the pairs in the same order, computed concurrently
+// for at most concurrency pairs at a time, default: 4.
+// The sides of a single pair are quoted as described in Spread.
+// If any pair fails, the spreads of the other pairs are returned along with the error
+// of the first failed pair.
+func (c *Client) Spreads(pairs []SpreadParams, concurrency int) ([]Spread, error) {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	spreads := make([]Spread, len(pairs))
+	errs := make([]error, len(pairs))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(len(pairs))
+	for i, params := range pairs {
+		go func(i int, params SpreadParams) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			spreads[i], errs[i] = c.Spread(params)
+		}(i, params)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return spreads, fmt.Errorf("pair %s/%s: %w", pairs[i].Base, pairs[i].Quote, err)
+		}
+	}
+
+	return spreads, nil
+}
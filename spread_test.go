@@ -0,0 +1,81 @@
+package jupiter_test
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpread(t *testing.T) {
+	srv := newTestServer(t, map[string]http.HandlerFunc{
+		"/quote": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			switch {
+			case q.Get("inputMint") == wSolMint.String():
+				serveFixture("testdata/quote.json")(w, r)
+			case q.Get("inputMint") == usdcMint.String() && q.Get("amount") == "2110":
+				w.Header().Set("Content-Type", jupiter.ContentTypeJSON)
+				_, _ = w.Write([]byte(`{"data":[{"inAmount":"2110","outAmount":"99500","swapMode":"ExactIn","marketInfos":[]}]}`))
+			default:
+				http.Error(w, "unexpected quote", http.StatusBadRequest)
+			}
+		},
+	})
+	c := jupiter.NewClient(
+		jupiter.WithAPIURL(srv.URL),
+		jupiter.WithTokenRegistry(jupiter.NewTokenMap([]jupiter.Token{
+			{Address: usdcMint.String(), Symbol: "USDC", Decimals: 6},
+		})),
+	)
+	params := jupiter.SpreadParams{
+		Base:        wSolMint,
+		Quote:       usdcMint,
+		BaseAmount:  100000,
+		QuoteAmount: 2110,
+	}
+
+	spread, err := c.Spread(params)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(211, 10), spread.Bid)
+	assert.Equal(t, big.NewRat(4220, 199), spread.Ask)
+	assert.Equal(t, "21.153015", spread.Mid.FloatString(6))
+	assert.InDelta(t, 50.1253, spread.SpreadBps, 0.0001)
+
+	t.Run("pairs", func(t *testing.T) {
+		failing := params
+		failing.QuoteAmount = 1000
+
+		spreads, err := c.Spreads([]jupiter.SpreadParams{params, failing}, 1)
+		require.Error(t, err)
+		require.Len(t, spreads, 2)
+		assert.Equal(t, spread.Mid, spreads[0].Mid)
+		assert.Nil(t, spreads[1].Mid)
+	})
+
+	t.Run("same notional", func(t *testing.T) {
+		params := params
+		params.QuoteAmount = 0
+
+		// The ask is quoted for the 2110 quote tokens received on the bid side.
+		derived, err := c.Spread(params)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2110), derived.AskRate.InAmount)
+		assert.Equal(t, spread.Mid, derived.Mid)
+	})
+
+	t.Run("unknown decimals", func(t *testing.T) {
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+		_, err := c.Spread(params)
+		require.ErrorIs(t, err, jupiter.ErrUnknownMint)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		_, err := c.Spread(jupiter.SpreadParams{Base: wSolMint, Quote: wSolMint, BaseAmount: 1})
+		require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+	})
+}
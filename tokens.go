@@ -0,0 +1,73 @@
+package jupiter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type (
+	// Token is a token list entry.
+	Token struct {
+		Address  string   `json:"address" drift:"required"`  // mint address
+		ChainID  int      `json:"chainId" drift:"required"`  // chain ID, 101 for mainnet-beta
+		Decimals uint8    `json:"decimals" drift:"required"` // number of decimals of the token
+		Name     string   `json:"name" drift:"required"`     // token name
+		Symbol   string   `json:"symbol" drift:"required"`   // token symbol
+		LogoURI  string   `json:"logoURI,omitempty"`
+		Tags     []string `json:"tags,omitempty"`
+	}
+
+	// TokenRegistry resolves token metadata by the mint address.
+	TokenRegistry interface {
+		Token(mint string) (Token, bool)
+	}
+
+	// TokenMap is a TokenRegistry backed by a map of tokens keyed by the mint address.
+	TokenMap map[string]Token
+)
+
+// NewTokenMap returns a token registry for the given tokens.
+func NewTokenMap(tokens []Token) TokenMap {
+	m := make(TokenMap, len(tokens))
+	for _, t := range tokens {
+		m[t.Address] = t
+	}
+	return m
+}
+
+// Token returns the token with the given mint address.
+func (m TokenMap) Token(mint string) (Token, bool) {
+	t, ok := m[mint]
+	return t, ok
+}
+
+// tokenSymbol returns the symbol of the token with the given mint address,
+// or an empty string if the registry is nil or the token is unknown.
+func tokenSymbol(tokens TokenRegistry, mint string) string {
+	if tokens == nil {
+		return ""
+	}
+	t, _ := tokens.Token(mint)
+	return t.Symbol
+}
+
+// TokenList returns the list of tokens from the token list URL, see WithTokenListURL.
+func (c *Client) TokenList() ([]Token, error) {
+	resp, err := c.getURL(context.Background(), c.tokenListURL, url.Values{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to make token list request: %w", err)
+	}
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token list response: %w", err)
+	}
+
+	var tokens []Token
+	if err := c.decode(body, &tokens, ""); err != nil {
+		return nil, fmt.Errorf("failed to parse token list response: %w", err)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,251 @@
+package jupiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+type (
+	// TWAPParams are the parameters of the TWAP order.
+	TWAPParams struct {
+		Swap           BestSwapParams // swap params, Swap.Amount is the total amount of the order
+		Slices         int            // number of slices the amount is split into
+		Window         time.Duration  // time window the slices are evenly spread over, zero executes the slices one by one without waiting
+		SkipHighImpact bool           // skip slices failing with ErrPriceImpactTooHigh instead of stopping, see BestSwapParams.MaxPriceImpactPct
+	}
+
+	// TWAPSlice is a processed slice of the TWAP order.
+	TWAPSlice struct {
+		Index   int    `json:"index"`            // index of the slice
+		Amount  uint64 `json:"amount"`           // amount of the slice
+		Skipped bool   `json:"skipped"`          // whether the slice was skipped
+		Reason  string `json:"reason,omitempty"` // reason the slice was skipped
+		Fill    *Fill  `json:"fill,omitempty"`   // fill of the executed slice
+	}
+
+	// TWAPState is the progress of the TWAP order, it can be stored to resume the order later.
+	TWAPState struct {
+		StartedAt time.Time   `json:"startedAt"` // start of the time window
+		Slices    []TWAPSlice `json:"slices"`    // processed slices in order
+		InAmount  uint64      `json:"inAmount"`  // total amount of input token sent
+		OutAmount uint64      `json:"outAmount"` // total amount of output token received
+	}
+
+	// TWAP executes an order in slices evenly spread over a time window,
+	// each slice is re-quoted and executed with the executor.
+	TWAP struct {
+		executor   Executor
+		params     TWAPParams
+		clock      Clock
+		onProgress func(TWAPState)
+
+		mu      sync.Mutex
+		state   TWAPState
+		running bool
+	}
+
+	// TWAPOption is a function that can be used to configure a TWAP order.
+	TWAPOption func(*TWAP)
+)
+
+// NewTWAP returns a new TWAP order executed with the given executor. Call Run to execute it.
+func NewTWAP(executor Executor, params TWAPParams, opts ...TWAPOption) (*TWAP, error) {
+	if executor == nil {
+		return nil, fmt.Errorf("%w: executor is required", ErrInvalidParams)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid twap params: %w", err)
+	}
+
+	t := &TWAP{
+		executor: executor,
+		params:   params,
+		clock:    executorClock(executor),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if err := t.state.check(params); err != nil {
+		return nil, fmt.Errorf("%w: resumed state doesn't match the order: %s", ErrInvalidParams, err)
+	}
+
+	return t, nil
+}
+
+// WithTWAPClock returns a TWAPOption that configures the clock.
+// Default: the client clock for SwapExecutor and PaperExecutor (see WithClock), the system clock otherwise.
+func WithTWAPClock(clock Clock) TWAPOption {
+	return func(t *TWAP) {
+		t.clock = clock
+	}
+}
+
+// executorClock returns the clock of the executor's client, so the slices are scheduled
+// by the same clock the fills are stamped with.
+func executorClock(executor Executor) Clock {
+	switch e := executor.(type) {
+	case *SwapExecutor:
+		return e.client.clock
+	case *PaperExecutor:
+		return e.client.clock
+	default:
+		return systemClock{}
+	}
+}
+
+// WithTWAPState returns a TWAPOption that resumes the order from the given state.
+// Processed slices are not executed again, the time window keeps its original start.
+func WithTWAPState(state TWAPState) TWAPOption {
+	return func(t *TWAP) {
+		t.state = state.clone()
+	}
+}
+
+// WithTWAPProgress returns a TWAPOption that configures the callback called with the state
+// after each processed slice, e.g. to store the state for resuming.
+func WithTWAPProgress(fn func(TWAPState)) TWAPOption {
+	return func(t *TWAP) {
+		t.onProgress = fn
+	}
+}
+
+// State returns the current state of the order.
+func (t *TWAP) State() TWAPState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state.clone()
+}
+
+// Run executes the remaining slices of the order, waiting for the scheduled time of each slice.
+// Slices behind schedule are executed right away. It returns the final state, or the state
+// and the error if the context is done or a slice fails; the failed slice is retried on resume.
+// Only one Run may be active at a time, otherwise ErrTWAPRunning is returned.
+func (t *TWAP) Run(ctx context.Context) (TWAPState, error) {
+	t.mu.Lock()
+	if t.running {
+		t.mu.Unlock()
+		return t.State(), ErrTWAPRunning
+	}
+	t.running = true
+	defer func() {
+		t.mu.Lock()
+		t.running = false
+		t.mu.Unlock()
+	}()
+	if t.state.StartedAt.IsZero() {
+		t.state.StartedAt = t.clock.Now()
+	}
+	next, startedAt := len(t.state.Slices), t.state.StartedAt
+	t.mu.Unlock()
+
+	for i := next; i < t.params.Slices; i++ {
+		at := startedAt.Add(t.params.Window * time.Duration(i) / time.Duration(t.params.Slices))
+		if d := at.Sub(t.clock.Now()); d > 0 {
+			select {
+			case <-ctx.Done():
+				return t.State(), ctx.Err()
+			case <-t.clock.After(d):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return t.State(), err
+		}
+
+		slice := TWAPSlice{Index: i, Amount: t.params.sliceAmount(i)}
+		params := t.params.Swap
+		params.Amount = slice.Amount
+
+		fill, err := t.executor.Execute(ctx, params)
+		switch {
+		case err == nil:
+			slice.Fill = &fill
+		case t.params.SkipHighImpact && errors.Is(err, ErrPriceImpactTooHigh):
+			slice.Skipped = true
+			slice.Reason = err.Error()
+		default:
+			return t.State(), fmt.Errorf("failed to execute slice %d: %w", i, err)
+		}
+
+		t.mu.Lock()
+		t.state.Slices = append(t.state.Slices, slice)
+		if slice.Fill != nil {
+			t.state.InAmount += slice.Fill.InAmount
+			t.state.OutAmount += slice.Fill.OutAmount
+		}
+		state := t.state.clone()
+		t.mu.Unlock()
+
+		if t.onProgress != nil {
+			t.onProgress(state)
+		}
+	}
+
+	return t.State(), nil
+}
+
+// sliceAmount returns the amount of the slice with the given index, the last slice takes the remainder.
+func (p TWAPParams) sliceAmount(i int) uint64 {
+	amount := p.Swap.Amount / uint64(p.Slices)
+	if i == p.Slices-1 {
+		amount += p.Swap.Amount % uint64(p.Slices)
+	}
+	return amount
+}
+
+// AveragePrice returns the average fill price as the amount of output token per one input token in base units,
+// or nil if nothing is filled yet.
+func (s TWAPState) AveragePrice() *big.Rat {
+	if s.InAmount == 0 {
+		return nil
+	}
+	return new(big.Rat).SetFrac(new(big.Int).SetUint64(s.OutAmount), new(big.Int).SetUint64(s.InAmount))
+}
+
+// Skipped returns the number of skipped slices.
+func (s TWAPState) Skipped() int {
+	var n int
+	for _, slice := range s.Slices {
+		if slice.Skipped {
+			n++
+		}
+	}
+	return n
+}
+
+// check returns an error if the processed slices don't match the order params.
+func (s TWAPState) check(params TWAPParams) error {
+	if len(s.Slices) > params.Slices {
+		return fmt.Errorf("state has %d slices, the order has %d", len(s.Slices), params.Slices)
+	}
+
+	var in, out uint64
+	for i, slice := range s.Slices {
+		if slice.Index != i {
+			return fmt.Errorf("slice %d has index %d", i, slice.Index)
+		}
+		if amount := params.sliceAmount(i); slice.Amount != amount {
+			return fmt.Errorf("slice %d has amount %d, the order slice amount is %d", i, slice.Amount, amount)
+		}
+		if slice.Fill != nil {
+			in += slice.Fill.InAmount
+			out += slice.Fill.OutAmount
+		}
+	}
+	if in != s.InAmount || out != s.OutAmount {
+		return fmt.Errorf("totals %d/%d don't match the fills %d/%d", s.InAmount, s.OutAmount, in, out)
+	}
+
+	return nil
+}
+
+// clone returns a copy of the state that doesn't share the slices.
+func (s TWAPState) clone() TWAPState {
+	s.Slices = append([]TWAPSlice(nil), s.Slices...)
+	return s
+}
@@ -0,0 +1,228 @@
+package jupiter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances the time instantly on every wait.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// fakeExecutor fills every swap at 20 output tokens per input token, failing with the errors per call.
+type fakeExecutor struct {
+	amounts []uint64
+	errs    map[int]error
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, params jupiter.BestSwapParams) (jupiter.Fill, error) {
+	call := len(e.amounts)
+	e.amounts = append(e.amounts, params.Amount)
+	if err := e.errs[call]; err != nil {
+		return jupiter.Fill{}, err
+	}
+	return jupiter.Fill{InAmount: params.Amount, OutAmount: params.Amount * 20}, nil
+}
+
+// executorFunc adapts the function to the executor interface.
+type executorFunc func(ctx context.Context, params jupiter.BestSwapParams) (jupiter.Fill, error)
+
+func (f executorFunc) Execute(ctx context.Context, params jupiter.BestSwapParams) (jupiter.Fill, error) {
+	return f(ctx, params)
+}
+
+func TestTWAP(t *testing.T) {
+	start := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	params := jupiter.TWAPParams{
+		Swap: jupiter.BestSwapParams{
+			UserPublicKey: userPublicKey,
+			InputMint:     wSolMint,
+			OutputMint:    usdcMint,
+			Amount:        1000,
+		},
+		Slices:         3,
+		Window:         time.Hour,
+		SkipHighImpact: true,
+	}
+
+	clock := &fakeClock{now: start}
+	executor := &fakeExecutor{errs: map[int]error{
+		1: fmt.Errorf("%w: 30%%", jupiter.ErrPriceImpactTooHigh),
+	}}
+	var progress []int
+	twap, err := jupiter.NewTWAP(executor, params,
+		jupiter.WithTWAPClock(clock),
+		jupiter.WithTWAPProgress(func(s jupiter.TWAPState) { progress = append(progress, len(s.Slices)) }),
+	)
+	require.NoError(t, err)
+
+	state, err := twap.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{333, 333, 334}, executor.amounts)
+	assert.Equal(t, []time.Duration{20 * time.Minute, 20 * time.Minute}, clock.waits)
+	assert.Equal(t, []int{1, 2, 3}, progress)
+
+	assert.Equal(t, start, state.StartedAt)
+	require.Len(t, state.Slices, 3)
+	assert.True(t, state.Slices[1].Skipped)
+	assert.Contains(t, state.Slices[1].Reason, "price impact too high")
+	assert.Equal(t, 1, state.Skipped())
+	assert.Equal(t, uint64(667), state.InAmount)
+	assert.Equal(t, uint64(13340), state.OutAmount)
+	assert.Equal(t, big.NewRat(20, 1), state.AveragePrice())
+
+	t.Run("resume", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+		failing := &fakeExecutor{errs: map[int]error{1: errors.New("rpc unavailable")}}
+
+		twap, err := jupiter.NewTWAP(failing, params, jupiter.WithTWAPClock(clock))
+		require.NoError(t, err)
+
+		state, err := twap.Run(context.Background())
+		require.Error(t, err)
+		require.Len(t, state.Slices, 1)
+
+		// Resumed after the window is over, the remaining slices are executed right away.
+		clock.now = start.Add(2 * time.Hour)
+		clock.waits = nil
+		resumed := &fakeExecutor{}
+		twap, err = jupiter.NewTWAP(resumed, params, jupiter.WithTWAPClock(clock), jupiter.WithTWAPState(state))
+		require.NoError(t, err)
+
+		state, err = twap.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{333, 334}, resumed.amounts)
+		assert.Empty(t, clock.waits)
+		assert.Len(t, state.Slices, 3)
+		assert.Equal(t, uint64(1000), state.InAmount)
+	})
+
+	t.Run("stop on high impact", func(t *testing.T) {
+		params := params
+		params.SkipHighImpact = false
+		executor := &fakeExecutor{errs: map[int]error{0: jupiter.ErrPriceImpactTooHigh}}
+
+		twap, err := jupiter.NewTWAP(executor, params, jupiter.WithTWAPClock(&fakeClock{now: start}))
+		require.NoError(t, err)
+
+		state, err := twap.Run(context.Background())
+		require.ErrorIs(t, err, jupiter.ErrPriceImpactTooHigh)
+		assert.Empty(t, state.Slices)
+		assert.Nil(t, state.AveragePrice())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		twap, err := jupiter.NewTWAP(&fakeExecutor{}, params, jupiter.WithTWAPClock(&fakeClock{now: start}))
+		require.NoError(t, err)
+
+		_, err = twap.Run(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("running", func(t *testing.T) {
+		entered, release := make(chan struct{}), make(chan struct{})
+		executor := executorFunc(func(ctx context.Context, params jupiter.BestSwapParams) (jupiter.Fill, error) {
+			entered <- struct{}{}
+			<-release
+			return jupiter.Fill{InAmount: params.Amount}, nil
+		})
+		params := params
+		params.Window = 0
+
+		twap, err := jupiter.NewTWAP(executor, params)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			_, err := twap.Run(context.Background())
+			done <- err
+		}()
+		<-entered
+
+		_, err = twap.Run(context.Background())
+		require.ErrorIs(t, err, jupiter.ErrTWAPRunning)
+
+		close(release)
+		for i := 1; i < params.Slices; i++ {
+			<-entered
+		}
+		require.NoError(t, <-done)
+		assert.Len(t, twap.State().Slices, 3)
+	})
+
+	t.Run("mismatched state", func(t *testing.T) {
+		state := jupiter.TWAPState{
+			StartedAt: start,
+			Slices:    []jupiter.TWAPSlice{{Index: 0, Amount: 500, Fill: &jupiter.Fill{InAmount: 500}}},
+			InAmount:  500,
+		}
+
+		_, err := jupiter.NewTWAP(&fakeExecutor{}, params, jupiter.WithTWAPState(state))
+		require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+
+		state.Slices[0].Amount = 333
+		state.InAmount = 400
+		_, err = jupiter.NewTWAP(&fakeExecutor{}, params, jupiter.WithTWAPState(state))
+		require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+	})
+
+	t.Run("client clock", func(t *testing.T) {
+		srv := newTestServer(t, map[string]http.HandlerFunc{
+			"/quote": serveFixture("testdata/quote.json"),
+		})
+		clock := &fakeClock{now: start}
+		c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL), jupiter.WithClock(clock))
+		ledger := jupiter.NewLedger(map[string]uint64{wSolMint.String(): 1e9})
+
+		twap, err := jupiter.NewTWAP(jupiter.NewPaperExecutor(c, ledger), params)
+		require.NoError(t, err)
+
+		state, err := twap.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{20 * time.Minute, 20 * time.Minute}, clock.waits)
+		require.Len(t, state.Slices, 3)
+		for i, slice := range state.Slices {
+			require.NotNil(t, slice.Fill)
+			assert.Equal(t, start.Add(time.Duration(i)*20*time.Minute), slice.Fill.At)
+		}
+	})
+
+	t.Run("no executor", func(t *testing.T) {
+		_, err := jupiter.NewTWAP(nil, params)
+		require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		params := params
+		params.Slices = 2000
+
+		_, err := jupiter.NewTWAP(&fakeExecutor{}, params)
+		var errs jupiter.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, "Swap.Amount", errs[0].Field)
+	})
+}
@@ -5,3 +5,9 @@ const (
 	SwapModeExactIn  = "ExactIn"
 	SwapModeExactOut = "ExactOut"
 )
+
+// WrappedSOLMint is the mint of the wrapped SOL token.
+var WrappedSOLMint = MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
+
+// LamportsPerSOL is the number of lamports in one SOL.
+const LamportsPerSOL = 1_000_000_000
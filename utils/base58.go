@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+)
+
+// base58Alphabet is the Bitcoin base58 alphabet used by Solana.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidBase58 is returned when a string contains characters outside of the base58 alphabet.
+	ErrInvalidBase58 = errors.New("invalid base58 string")
+
+	base58Radix   = big.NewInt(58)
+	base58Indexes = func() [256]int {
+		var idx [256]int
+		for i := range idx {
+			idx[i] = -1
+		}
+		for i := 0; i < len(base58Alphabet); i++ {
+			idx[base58Alphabet[i]] = i
+		}
+		return idx
+	}()
+)
+
+// Base58Encode encodes the given bytes to base58 string.
+func Base58Encode(b []byte) string {
+	// Leading zero bytes are encoded as leading '1' characters.
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	num := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+	result := make([]byte, 0, len(b)*138/100+1)
+	for num.Sign() > 0 {
+		num.DivMod(num, base58Radix, mod)
+		result = append(result, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		result = append(result, base58Alphabet[0])
+	}
+
+	// Reverse the result.
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return string(result)
+}
+
+// Base58Decode decodes the given base58 string to bytes.
+func Base58Decode(s string) ([]byte, error) {
+	// Leading '1' characters are decoded as leading zero bytes.
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	num := new(big.Int)
+	digit := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		idx := base58Indexes[s[i]]
+		if idx < 0 {
+			return nil, ErrInvalidBase58
+		}
+		num.Mul(num, base58Radix)
+		num.Add(num, digit.SetInt64(int64(idx)))
+	}
+
+	result := make([]byte, zeros, zeros+len(num.Bytes()))
+	result = append(result, num.Bytes()...)
+
+	return result, nil
+}
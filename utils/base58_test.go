@@ -0,0 +1,51 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/dmitrymomot/jupiter/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		name    string
+		decoded []byte
+		encoded string
+	}{
+		{
+			name:    "empty",
+			decoded: []byte{},
+			encoded: "",
+		},
+		{
+			name:    "leading zeros",
+			decoded: []byte{0, 0, 1},
+			encoded: "112",
+		},
+		{
+			name:    "hello world",
+			decoded: []byte("hello world"),
+			encoded: "StV1DL6CwTryKyV",
+		},
+		{
+			name:    "zero public key",
+			decoded: make([]byte, 32),
+			encoded: "11111111111111111111111111111111",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.encoded, utils.Base58Encode(tt.decoded))
+
+			decoded, err := utils.Base58Decode(tt.encoded)
+			require.NoError(t, err)
+			require.Equal(t, tt.decoded, decoded)
+		})
+	}
+
+	t.Run("invalid character", func(t *testing.T) {
+		_, err := utils.Base58Decode("0OIl")
+		require.ErrorIs(t, err, utils.ErrInvalidBase58)
+	})
+}
@@ -0,0 +1,174 @@
+package jupiter
+
+import "errors"
+
+// MaxBps is the maximum value of basis points, i.e. 100%.
+const MaxBps = 10000
+
+// requirePublicKey adds a validation error if the given public key is not set.
+// The System Program address is the zero key, so it's rejected too, see PublicKey.IsZero.
+func (e *ValidationErrors) requirePublicKey(field string, pk PublicKey) {
+	if pk.IsZero() {
+		e.add(field, "is required")
+	}
+}
+
+// requireAmount adds a validation error if the given amount is zero.
+func (e *ValidationErrors) requireAmount(field string, amount uint64) {
+	if amount == 0 {
+		e.add(field, "must be greater than 0")
+	}
+}
+
+// checkMints adds a validation error if input and output mints are the same.
+func (e *ValidationErrors) checkMints(input, output Mint) {
+	if !input.IsZero() && input == output {
+		e.add("OutputMint", "must differ from InputMint")
+	}
+}
+
+// checkSwapMode adds a validation error if the swap mode is set and unknown.
+func (e *ValidationErrors) checkSwapMode(field, mode string) {
+	if mode != "" && mode != SwapModeExactIn && mode != SwapModeExactOut {
+		e.add(field, "must be one of: "+SwapModeExactIn+", "+SwapModeExactOut)
+	}
+}
+
+// checkBps adds a validation error if the given basis points value is out of range.
+func (e *ValidationErrors) checkBps(field string, bps uint64) {
+	if bps > MaxBps {
+		e.add(field, "must be between 0 and 10000")
+	}
+}
+
+// Validate validates the quote params.
+func (p QuoteParams) Validate() error {
+	var errs ValidationErrors
+	errs.requirePublicKey("InputMint", p.InputMint)
+	errs.requirePublicKey("OutputMint", p.OutputMint)
+	errs.checkMints(p.InputMint, p.OutputMint)
+	errs.requireAmount("Amount", p.Amount)
+	errs.checkSwapMode("SwapMode", p.SwapMode)
+	errs.checkBps("SlippageBps", p.SlippageBps)
+	errs.checkBps("FeeBps", p.FeeBps)
+	if len(p.Dexes) > 0 && len(p.ExcludeDexes) > 0 {
+		errs.add("ExcludeDexes", "must not be set together with Dexes")
+	}
+	return errs.err()
+}
+
+// Validate validates the swap params.
+func (p SwapParams) Validate() error {
+	var errs ValidationErrors
+	if len(p.Route.MarketInfos) == 0 {
+		errs.add("Route", "is required")
+	}
+	errs.requirePublicKey("UserPublicKey", p.UserPublicKey)
+	if p.FeeAccount != nil {
+		errs.requirePublicKey("FeeAccount", *p.FeeAccount)
+	}
+	if p.DestinationWallet != nil {
+		errs.requirePublicKey("DestinationWallet", *p.DestinationWallet)
+	}
+	if p.ComputeUnitPriceMicroLamports != nil && *p.ComputeUnitPriceMicroLamports < 0 {
+		errs.add("ComputeUnitPriceMicroLamports", "must not be negative")
+	}
+	return errs.err()
+}
+
+// Validate validates the price params.
+func (p PriceParams) Validate() error {
+	var errs ValidationErrors
+	if p.IDs == "" {
+		errs.add("IDs", "is required")
+	}
+	if p.VsAmount < 0 {
+		errs.add("VsAmount", "must not be negative")
+	}
+	return errs.err()
+}
+
+// Validate validates the best swap params.
+func (p BestSwapParams) Validate() error {
+	var errs ValidationErrors
+	errs.requirePublicKey("UserPublicKey", p.UserPublicKey)
+	errs.requirePublicKey("InputMint", p.InputMint)
+	errs.requirePublicKey("OutputMint", p.OutputMint)
+	errs.checkMints(p.InputMint, p.OutputMint)
+	errs.requireAmount("Amount", p.Amount)
+	errs.checkSwapMode("SwapMode", p.SwapMode)
+	errs.checkBps("FeeAmount", p.FeeAmount)
+	if p.FeeAmount > 0 && p.FeeAccount.IsZero() {
+		errs.add("FeeAccount", "is required when FeeAmount is set")
+	}
+	errs.checkBps("SlippageBps", p.SlippageBps)
+	if p.MaxPriceImpactPct < 0 || p.MaxPriceImpactPct > 1 {
+		errs.add("MaxPriceImpactPct", "must be between 0 and 1")
+	}
+	if p.ComputeUnitPriceMicroLamports != nil && *p.ComputeUnitPriceMicroLamports < 0 {
+		errs.add("ComputeUnitPriceMicroLamports", "must not be negative")
+	}
+	return errs.err()
+}
+
+// Validate validates the exchange rate params.
+func (p ExchangeRateParams) Validate() error {
+	var errs ValidationErrors
+	errs.requirePublicKey("InputMint", p.InputMint)
+	errs.requirePublicKey("OutputMint", p.OutputMint)
+	errs.checkMints(p.InputMint, p.OutputMint)
+	errs.requireAmount("Amount", p.Amount)
+	errs.checkSwapMode("SwapMode", p.SwapMode)
+	return errs.err()
+}
+
+// Validate validates the spread params.
+func (p SpreadParams) Validate() error {
+	var errs ValidationErrors
+	errs.requirePublicKey("Base", p.Base)
+	errs.requirePublicKey("Quote", p.Quote)
+	if !p.Base.IsZero() && p.Base == p.Quote {
+		errs.add("Quote", "must differ from Base")
+	}
+	errs.requireAmount("BaseAmount", p.BaseAmount)
+	return errs.err()
+}
+
+// Validate validates the depth params.
+func (p DepthParams) Validate() error {
+	var errs ValidationErrors
+	errs.requirePublicKey("InputMint", p.InputMint)
+	errs.requirePublicKey("OutputMint", p.OutputMint)
+	errs.checkMints(p.InputMint, p.OutputMint)
+	errs.requireAmount("MinAmount", p.MinAmount)
+	if p.MaxAmount <= p.MinAmount {
+		errs.add("MaxAmount", "must be greater than MinAmount")
+	}
+	if p.Steps != 0 && p.Steps < 2 {
+		errs.add("Steps", "must be at least 2")
+	}
+	if p.Scale != "" && p.Scale != DepthScaleLinear && p.Scale != DepthScaleGeometric {
+		errs.add("Scale", "must be one of: "+DepthScaleLinear+", "+DepthScaleGeometric)
+	}
+	return errs.err()
+}
+
+// Validate validates the TWAP params.
+func (p TWAPParams) Validate() error {
+	var errs ValidationErrors
+	var swapErrs ValidationErrors
+	if errors.As(p.Swap.Validate(), &swapErrs) {
+		for _, err := range swapErrs {
+			errs.add("Swap."+err.Field, err.Message)
+		}
+	}
+	if p.Slices < 1 {
+		errs.add("Slices", "must be greater than 0")
+	} else if p.Swap.Amount > 0 && p.Swap.Amount < uint64(p.Slices) {
+		errs.add("Swap.Amount", "must be at least the number of slices")
+	}
+	if p.Window < 0 {
+		errs.add("Window", "must not be negative")
+	}
+	return errs.err()
+}
@@ -0,0 +1,109 @@
+package jupiter_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dmitrymomot/jupiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldNames returns the names of invalid fields from a validation error.
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+
+	var verrs jupiter.ValidationErrors
+	require.True(t, errors.As(err, &verrs), "expected validation errors, got: %v", err)
+
+	fields := make([]string, 0, len(verrs))
+	for _, verr := range verrs {
+		fields = append(fields, verr.Field)
+	}
+	return fields
+}
+
+func TestQuoteParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params jupiter.QuoteParams
+		fields []string
+	}{
+		{
+			name:   "valid",
+			params: jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 1},
+		},
+		{
+			name:   "empty",
+			params: jupiter.QuoteParams{},
+			fields: []string{"InputMint", "OutputMint", "Amount"},
+		},
+		{
+			name:   "same mints",
+			params: jupiter.QuoteParams{InputMint: wSolMint, OutputMint: wSolMint, Amount: 1},
+			fields: []string{"OutputMint"},
+		},
+		{
+			name: "invalid enums and bps",
+			params: jupiter.QuoteParams{
+				InputMint:   wSolMint,
+				OutputMint:  usdcMint,
+				Amount:      1,
+				SwapMode:    "exactIn",
+				SlippageBps: 10001,
+				FeeBps:      20000,
+			},
+			fields: []string{"SwapMode", "SlippageBps", "FeeBps"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.fields == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+			assert.Equal(t, tt.fields, fieldNames(t, err))
+		})
+	}
+}
+
+func TestBestSwapParamsValidate(t *testing.T) {
+	err := jupiter.BestSwapParams{
+		UserPublicKey: userPublicKey,
+		InputMint:     wSolMint,
+		OutputMint:    usdcMint,
+		Amount:        1,
+		FeeAmount:     50,
+	}.Validate()
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+	assert.Equal(t, []string{"FeeAccount"}, fieldNames(t, err))
+}
+
+func TestClientValidatesParams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	c := jupiter.NewClient(jupiter.WithAPIURL(srv.URL))
+
+	_, err := c.Quote(jupiter.QuoteParams{InputMint: wSolMint, OutputMint: usdcMint})
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+	assert.Equal(t, []string{"Amount"}, fieldNames(t, err))
+
+	_, err = c.Swap(jupiter.SwapParams{UserPublicKey: userPublicKey})
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+
+	_, err = c.Price(jupiter.PriceParams{})
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+
+	_, err = c.BestSwap(jupiter.BestSwapParams{InputMint: wSolMint, OutputMint: usdcMint, Amount: 100})
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+
+	_, err = c.ExchangeRate(jupiter.ExchangeRateParams{InputMint: wSolMint, OutputMint: usdcMint, SwapMode: "Unknown"})
+	require.ErrorIs(t, err, jupiter.ErrInvalidParams)
+}